@@ -0,0 +1,49 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-ecs/ecs"
+)
+
+// ApplyCommand executes a change set created by `plan`
+func ApplyCommand(backend *ecs.ComposeECS) *cobra.Command {
+	var opts projectOptions
+	cmd := &cobra.Command{
+		Use:   "apply [OPTIONS] CHANGESET",
+		Short: "Execute a change set created by `plan`, and wait for the deployment to complete",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// change set ARN identifies the stack, a name has to be resolved in project stack
+			var stack string
+			if !arn.IsARN(args[0]) {
+				var err error
+				stack, err = opts.toProjectName()
+				if err != nil {
+					return err
+				}
+			}
+			return backend.Apply(cmd.Context(), stack, args[0])
+		},
+	}
+	opts.addFlags(cmd.Flags())
+	cmd.Flags().StringVar(&backend.UpOptions.OnInterrupt, "on-interrupt", "", `Policy when interrupted: "delete" the stack, "rollback" an update or "detach". By default, updates are rolled back and you are asked whether a new stack should be deleted`)
+	return cmd
+}
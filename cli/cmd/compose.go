@@ -0,0 +1,42 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-ecs/ecs"
+)
+
+// AddComposeFlags adds ECS specific flags and subcommands to a compose command
+func AddComposeFlags(c *cobra.Command, backend *ecs.ComposeECS) {
+	switch c.Name() {
+	case "down":
+		c.Flags().BoolVarP(&backend.DownOptions.Yes, "yes", "y", false, "Don't ask for confirmation before deleting volumes")
+		c.Flags().BoolVar(&backend.DownOptions.DryRun, "dry-run", false, "List resources which would be removed, without removing them")
+	case "events":
+		c.Flags().BoolVarP(&backend.EventsOptions.Follow, "follow", "f", false, "Follow events")
+	case "kill":
+		c.Flags().StringVar(&backend.KillOptions.Reason, "reason", "", "Reason recorded as tasks stopped reason")
+		c.Flags().StringSliceVar(&backend.KillOptions.Tasks, "task", nil, "Only kill tasks with given ID")
+	case "logs":
+		c.AddCommand(LogsQueryCommand(backend))
+	case "up":
+		c.Flags().BoolVar(&backend.UpOptions.DryRun, "dry-run", false, "Create a change set and list its changes, without deploying. Fails if resources would be replaced")
+		c.Flags().StringVar(&backend.UpOptions.OnInterrupt, "on-interrupt", "", `Policy when interrupted: "delete" the stack, "rollback" an update or "detach". By default, updates are rolled back and you are asked whether a new stack should be deleted`)
+	}
+}
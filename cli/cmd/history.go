@@ -0,0 +1,130 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/docker/compose/v2/cmd/formatter"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-ecs/ecs"
+)
+
+// projectHashLength is the length of project hash displayed, as docker does for IDs
+const projectHashLength = 12
+
+type historyOptions struct {
+	projectOptions
+	format string
+}
+
+// HistoryCommand lists the templates deployed for a project
+func HistoryCommand(backend *ecs.ComposeECS) *cobra.Command {
+	var opts historyOptions
+	cmd := &cobra.Command{
+		Use:   "history [OPTIONS]",
+		Short: "List the revisions deployed for the project",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHistory(cmd.Context(), backend, opts)
+		},
+	}
+	opts.addFlags(cmd.Flags())
+	cmd.Flags().StringVar(&opts.format, "format", formatter.PRETTY, "Format the output. Values: [pretty | json]. (Default: pretty)")
+	return cmd
+}
+
+func runHistory(ctx context.Context, backend *ecs.ComposeECS, opts historyOptions) error {
+	name, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+	revisions, err := backend.History(ctx, name)
+	if err != nil {
+		return err
+	}
+	view := viewFromRevisions(revisions, time.Now())
+	return formatter.Print(revisions, opts.format, os.Stdout, func(w io.Writer) {
+		for _, r := range view {
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", r.Revision, r.Deployed, r.ProjectHash, r.Version, r.User)
+		}
+	}, "REVISION", "DEPLOYED", "PROJECT HASH", "VERSION", "USER")
+}
+
+type revisionView struct {
+	Revision    int
+	Deployed    string
+	ProjectHash string
+	Version     string
+	User        string
+}
+
+func viewFromRevisions(revisions []ecs.DeploymentRevision, now time.Time) []revisionView {
+	view := make([]revisionView, len(revisions))
+	for i, r := range revisions {
+		hash := r.ProjectHash
+		if len(hash) > projectHashLength {
+			hash = hash[:projectHashLength]
+		}
+		if hash == "" {
+			hash = "-"
+		}
+		view[i] = revisionView{
+			Revision:    r.Revision,
+			Deployed:    units.HumanDuration(now.Sub(r.Deployed)) + " ago",
+			ProjectHash: hash,
+			Version:     r.Version,
+			User:        r.User,
+		}
+	}
+	return view
+}
+
+// RollbackCommand redeploys a previous revision of a project
+func RollbackCommand(backend *ecs.ComposeECS) *cobra.Command {
+	var opts projectOptions
+	cmd := &cobra.Command{
+		Use:   "rollback [OPTIONS] [REVISION]",
+		Short: "Redeploy a revision listed by `history`, by default the one before the latest, and wait for the deployment to complete",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var revision int
+			if len(args) > 0 {
+				var err error
+				revision, err = strconv.Atoi(args[0])
+				if err != nil || revision < 1 {
+					return fmt.Errorf("invalid revision %q", args[0])
+				}
+			}
+			name, err := opts.toProjectName()
+			if err != nil {
+				return err
+			}
+			return backend.Rollback(cmd.Context(), name, revision)
+		},
+	}
+	opts.addFlags(cmd.Flags())
+	cmd.Flags().StringVar(&backend.UpOptions.OnInterrupt, "on-interrupt", "", `Policy when interrupted: "delete" the stack, "rollback" an update or "detach". By default, updates are rolled back and you are asked whether a new stack should be deleted`)
+	return cmd
+}
@@ -0,0 +1,124 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/cli/opts"
+	"github.com/docker/compose/v2/cmd/formatter"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-ecs/ecs"
+)
+
+var acceptedListFilters = map[string]bool{
+	"name":   true,
+	"status": true,
+}
+
+type listOptions struct {
+	all    bool
+	filter opts.FilterOpt
+	format string
+	quiet  bool
+}
+
+// ListCommand lists projects deployed on ECS
+func ListCommand(backend *ecs.ComposeECS) *cobra.Command {
+	opts := listOptions{filter: opts.NewFilterOpt()}
+	cmd := &cobra.Command{
+		Use:   "ls [OPTIONS]",
+		Short: "List running compose projects",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd.Context(), backend, opts)
+		},
+	}
+	cmd.Flags().BoolVarP(&opts.all, "all", "a", false, "Show all projects, including failed and recently deleted ones")
+	cmd.Flags().Var(&opts.filter, "filter", "Filter output based on conditions provided (name, status)")
+	cmd.Flags().StringVar(&opts.format, "format", formatter.PRETTY, "Format the output. Values: [pretty | json]. (Default: pretty)")
+	cmd.Flags().BoolVarP(&opts.quiet, "quiet", "q", false, "Only display project names")
+	return cmd
+}
+
+func runList(ctx context.Context, backend *ecs.ComposeECS, opts listOptions) error {
+	filter := opts.filter.Value()
+	if err := filter.Validate(acceptedListFilters); err != nil {
+		return err
+	}
+	projects, err := backend.ListProjects(ctx, opts.all)
+	if err != nil {
+		return err
+	}
+	projects = filterProjects(projects, filter)
+	if opts.quiet {
+		for _, p := range projects {
+			fmt.Println(p.Name)
+		}
+		return nil
+	}
+	view := viewFromProjectList(projects, time.Now())
+	return formatter.Print(projects, opts.format, os.Stdout, func(w io.Writer) {
+		for _, p := range view {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", p.Name, p.Status, p.Services, p.Replicas, p.LastDeployment)
+		}
+	}, "NAME", "STATUS", "SERVICES", "REPLICAS", "LAST DEPLOYMENT")
+}
+
+func filterProjects(projects []ecs.ProjectSummary, filter filters.Args) []ecs.ProjectSummary {
+	var filtered []ecs.ProjectSummary
+	for _, p := range projects {
+		if filter.Match("name", p.Name) && filter.ExactMatch("status", strings.ToLower(p.Status)) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+type projectView struct {
+	Name           string
+	Status         string
+	Services       int
+	Replicas       string
+	LastDeployment string
+}
+
+func viewFromProjectList(projects []ecs.ProjectSummary, now time.Time) []projectView {
+	retList := make([]projectView, len(projects))
+	for i, p := range projects {
+		status := p.Status
+		if p.Reason != "" {
+			status = fmt.Sprintf("%s (%s)", p.Status, p.Reason)
+		}
+		retList[i] = projectView{
+			Name:           p.Name,
+			Status:         status,
+			Services:       p.Services,
+			Replicas:       fmt.Sprintf("%d/%d", p.Running, p.Desired),
+			LastDeployment: units.HumanDuration(now.Sub(p.LastDeployment)) + " ago",
+		}
+	}
+	return retList
+}
@@ -0,0 +1,111 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/docker/compose/v2/cmd/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-ecs/ecs"
+)
+
+// logsQueryHiddenFields are returned by Logs Insights queries but are not relevant to users
+var logsQueryHiddenFields = map[string]bool{
+	"@ptr": true,
+}
+
+type logsQueryOptions struct {
+	projectOptions
+	since  string
+	until  string
+	format string
+}
+
+// LogsQueryCommand runs a CloudWatch Logs Insights query against project logs
+func LogsQueryCommand(backend *ecs.ComposeECS) *cobra.Command {
+	var opts logsQueryOptions
+	cmd := &cobra.Command{
+		Use:   "query [OPTIONS] QUERY [SERVICE...]",
+		Short: "Search project logs with a CloudWatch Logs Insights query",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogsQuery(cmd.Context(), backend, opts, args[0], args[1:])
+		},
+	}
+	opts.addFlags(cmd.Flags())
+	cmd.Flags().StringVar(&opts.since, "since", "1h", "Query logs since timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
+	cmd.Flags().StringVar(&opts.until, "until", "", "Query logs before a timestamp (e.g. 2013-01-02T13:23:37Z) or relative (e.g. 42m for 42 minutes)")
+	cmd.Flags().StringVar(&opts.format, "format", formatter.PRETTY, "Format the output. Values: [pretty | json]. (Default: pretty)")
+	return cmd
+}
+
+func runLogsQuery(ctx context.Context, backend *ecs.ComposeECS, opts logsQueryOptions, query string, services []string) error {
+	name, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+	rows, err := backend.QueryLogs(ctx, name, ecs.LogQueryOptions{
+		Query:    query,
+		Services: services,
+		Since:    opts.since,
+		Until:    opts.until,
+	})
+	if err != nil {
+		return err
+	}
+	fields, view := viewFromLogQueryRows(rows)
+	headers := make([]string, len(fields))
+	for i, f := range fields {
+		headers[i] = strings.ToUpper(strings.TrimPrefix(f, "@"))
+	}
+	return formatter.Print(view, opts.format, os.Stdout, func(w io.Writer) {
+		for _, row := range view {
+			values := make([]string, len(fields))
+			for i, f := range fields {
+				values[i] = row[f]
+			}
+			_, _ = fmt.Fprintln(w, strings.Join(values, "\t"))
+		}
+	}, headers...)
+}
+
+// viewFromLogQueryRows returns fields of query results, in order of first appearance, and rows as maps
+func viewFromLogQueryRows(rows []ecs.LogQueryRow) ([]string, []map[string]string) {
+	var fields []string
+	known := map[string]bool{}
+	view := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		view[i] = map[string]string{}
+		for _, f := range row {
+			if logsQueryHiddenFields[f.Field] {
+				continue
+			}
+			if !known[f.Field] {
+				known[f.Field] = true
+				fields = append(fields, f.Field)
+			}
+			view[i][f.Field] = f.Value
+		}
+	}
+	return fields, view
+}
@@ -0,0 +1,42 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-ecs/ecs"
+)
+
+// PlanCommand previews the changes `up` would deploy, as `up --dry-run` does
+func PlanCommand(backend *ecs.ComposeECS) *cobra.Command {
+	var opts projectOptions
+	cmd := &cobra.Command{
+		Use:   "plan [OPTIONS]",
+		Short: "Create a CloudFormation change set for the project and list its changes, without executing it. Fails if resources would be replaced.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project, err := opts.toProject()
+			if err != nil {
+				return err
+			}
+			return backend.Plan(cmd.Context(), project)
+		},
+	}
+	opts.addFlags(cmd.Flags())
+	return cmd
+}
@@ -0,0 +1,62 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/compose-spec/compose-go/cli"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/pflag"
+)
+
+// projectOptions select the compose project for ECS specific commands
+type projectOptions struct {
+	name        string
+	configPaths []string
+	workDir     string
+}
+
+func (o *projectOptions) addFlags(f *pflag.FlagSet) {
+	f.StringVarP(&o.name, "project-name", "p", "", "Project name")
+	f.StringArrayVarP(&o.configPaths, "file", "f", nil, "Compose configuration files")
+	f.StringVar(&o.workDir, "project-directory", "", "Specify an alternate working directory\n(default: the path of the, first specified, Compose file)")
+}
+
+func (o *projectOptions) toProjectName() (string, error) {
+	if o.name != "" {
+		return o.name, nil
+	}
+	project, err := o.toProject()
+	if err != nil {
+		return "", err
+	}
+	return project.Name, nil
+}
+
+func (o *projectOptions) toProject() (*types.Project, error) {
+	options, err := cli.NewProjectOptions(o.configPaths,
+		cli.WithName(o.name),
+		cli.WithWorkingDirectory(o.workDir),
+		cli.WithOsEnv,
+		cli.WithDotEnv,
+		cli.WithConfigFileEnv,
+		cli.WithDefaultConfigPath,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return cli.ProjectFromOptions(options)
+}
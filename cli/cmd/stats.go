@@ -0,0 +1,111 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/docker/compose/v2/cmd/formatter"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-ecs/ecs"
+)
+
+const statsRefreshInterval = 10 * time.Second
+
+type statsOptions struct {
+	projectOptions
+	format   string
+	noStream bool
+}
+
+// StatsCommand displays services resources usage
+func StatsCommand(backend *ecs.ComposeECS) *cobra.Command {
+	var opts statsOptions
+	cmd := &cobra.Command{
+		Use:   "stats [OPTIONS] [SERVICE...]",
+		Short: "Display a live stream of services resources usage, from CloudWatch metrics.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStats(cmd.Context(), backend, opts, args)
+		},
+	}
+	opts.addFlags(cmd.Flags())
+	cmd.Flags().StringVar(&opts.format, "format", formatter.PRETTY, "Format the output. Values: [pretty | json]. (Default: pretty)")
+	cmd.Flags().BoolVar(&opts.noStream, "no-stream", false, "Disable streaming stats and only pull the first result")
+	return cmd
+}
+
+func runStats(ctx context.Context, backend *ecs.ComposeECS, opts statsOptions, services []string) error {
+	name, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(statsRefreshInterval)
+	defer ticker.Stop()
+	for {
+		stats, err := backend.Stats(ctx, name, services)
+		if err != nil {
+			return err
+		}
+		if !opts.noStream {
+			// clear screen, as docker stats does
+			fmt.Fprint(os.Stdout, "\033[2J\033[H")
+		}
+		view := viewFromServiceStats(stats)
+		err = formatter.Print(view, opts.format, os.Stdout, func(w io.Writer) {
+			for _, s := range view {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", s.Service, s.Tasks, s.CPUPercent, s.CPUUsage, s.MemoryPercent, s.MemoryUsage)
+			}
+		}, "SERVICE", "TASKS", "CPU %", "CPU USAGE / RESERVED", "MEM %", "MEM USAGE / RESERVED")
+		if err != nil || opts.noStream {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+type serviceStatsView struct {
+	Service       string
+	Tasks         string
+	CPUPercent    string
+	CPUUsage      string
+	MemoryPercent string
+	MemoryUsage   string
+}
+
+func viewFromServiceStats(stats []ecs.ServiceStats) []serviceStatsView {
+	retList := make([]serviceStatsView, len(stats))
+	for i, s := range stats {
+		retList[i] = serviceStatsView{
+			Service:       s.Service,
+			Tasks:         fmt.Sprintf("%d/%d", s.Running, s.Desired),
+			CPUPercent:    fmt.Sprintf("%.2f%%", s.CPUPercent()),
+			CPUUsage:      fmt.Sprintf("%s / %s", ecs.FormatCPU(s.CPUUtilized), ecs.FormatCPU(s.CPUReserved)),
+			MemoryPercent: fmt.Sprintf("%.2f%%", s.MemoryPercent()),
+			MemoryUsage:   fmt.Sprintf("%s / %s", ecs.FormatMemory(s.MemoryUtilized), ecs.FormatMemory(s.MemoryReserved)),
+		}
+	}
+	return retList
+}
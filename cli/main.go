@@ -62,11 +62,22 @@ func main() {
 	}
 	backend.WithBackend(service)
 
+	root.AddCommand(
+		cmd.ListCommand(service),
+		cmd.StatsCommand(service),
+		cmd.PlanCommand(service),
+		cmd.DiffCommand(service),
+		cmd.HistoryCommand(service),
+		cmd.RollbackCommand(service),
+		cmd.ApplyCommand(service),
+	)
+
 	command := compose.RootCommand(service.ComposeService())
 
 	for _, c := range command.Commands() {
 		switch c.Name() {
-		case "convert", "down", "logs", "ps", "up": // compose-ecs only implement a subset of compose commands
+		case "convert", "cp", "down", "events", "exec", "images", "kill", "logs", "pause", "port", "ps", "restart", "run", "start", "stop", "top", "unpause", "up": // compose-ecs only implement a subset of compose commands
+			cmd.AddComposeFlags(c, service)
 			root.AddCommand(c)
 		}
 	}
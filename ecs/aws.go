@@ -18,9 +18,12 @@ package ecs
 
 import (
 	"context"
+	"time"
 
+	"github.com/aws/aws-sdk-go/service/applicationautoscaling"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/docker/compose/v2/pkg/api"
 
 	"github.com/docker/compose-ecs/api/secrets"
@@ -29,6 +32,10 @@ import (
 const (
 	awsTypeCapacityProvider = "AWS::ECS::CapacityProvider"
 	awsTypeAutoscalingGroup = "AWS::AutoScaling::AutoScalingGroup"
+	awsTypeService          = "AWS::ECS::Service"
+	awsTypeTaskDefinition   = "AWS::ECS::TaskDefinition"
+	awsTypeAlarm            = "AWS::CloudWatch::Alarm"
+	awsTypeListener         = "AWS::ElasticLoadBalancingV2::Listener"
 )
 
 //go:generate mockgen -destination=./aws_mock.go -self_package "github.com/docker/compose-ecs/ecs" -package=ecs . API
@@ -45,17 +52,38 @@ type API interface {
 	GetRoleArn(ctx context.Context, name string) (string, error)
 	StackExists(ctx context.Context, name string) (bool, error)
 	CreateStack(ctx context.Context, name string, region string, template []byte) error
-	CreateChangeSet(ctx context.Context, name string, region string, template []byte) (string, error)
+	CreateChangeSet(ctx context.Context, name string, changeSetType string, region string, template []byte) (string, error)
+	DescribeChangeSet(ctx context.Context, stack string, changeset string) (changeSet, error)
 	UpdateStack(ctx context.Context, changeset string) error
+	CancelUpdateStack(ctx context.Context, name string) error
+	GetStackStatus(ctx context.Context, name string) (string, error)
 	WaitStackComplete(ctx context.Context, name string, operation int) error
 	GetStackID(ctx context.Context, name string) (string, error)
-	ListStacks(ctx context.Context) ([]api.Stack, error)
+	GetTemplate(ctx context.Context, name string, changeset string) ([]byte, error)
+	ListStacks(ctx context.Context, all bool) ([]projectStack, error)
 	GetStackClusterID(ctx context.Context, stack string) (string, error)
 	GetStackMetadataClusterID(ctx context.Context, stack string) (string, error)
 	GetServiceTaskDefinition(ctx context.Context, cluster string, serviceArns []string) (map[string]string, error)
 	ListStackServices(ctx context.Context, stack string) ([]string, error)
 	GetServiceTasks(ctx context.Context, cluster string, service string, stopped bool) ([]*ecs.Task, error)
 	GetTaskStoppedReason(ctx context.Context, cluster string, taskArn string) (string, error)
+	ExecuteCommand(ctx context.Context, cluster string, taskArn string, container string, command string) (*ecs.Session, error)
+	DescribeTask(ctx context.Context, cluster string, taskArn string) (*ecs.Task, error)
+	DescribeTaskDefinition(ctx context.Context, arn string) (*ecs.TaskDefinition, []*ecs.Tag, error)
+	RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (string, error)
+	DeregisterTaskDefinition(ctx context.Context, arn string) error
+	RunTask(ctx context.Context, input *ecs.RunTaskInput) (string, error)
+	StopTask(ctx context.Context, cluster string, taskArn string, reason string) error
+	ScaleService(ctx context.Context, cluster string, serviceArn string, desiredCount int) error
+	WaitServicesStable(ctx context.Context, cluster string, serviceArns []string) error
+	GetServiceTags(ctx context.Context, serviceArn string) (map[string]string, error)
+	TagService(ctx context.Context, serviceArn string, tags map[string]string) error
+	UntagService(ctx context.Context, serviceArn string, keys []string) error
+	GetServiceScalableTarget(ctx context.Context, serviceArn string) (*applicationautoscaling.ScalableTarget, error)
+	UpdateServiceScalableTarget(ctx context.Context, serviceArn string, minCapacity int64, suspended bool) error
+	ForceNewDeployment(ctx context.Context, cluster string, serviceArn string) error
+	DescribeServices(ctx context.Context, cluster string, serviceArns []string) ([]*ecs.Service, error)
+	GetServiceMetrics(ctx context.Context, cluster string, service string) (serviceMetrics, error)
 	DescribeStackEvents(ctx context.Context, stackID string) ([]*cloudformation.StackEvent, error)
 	ListStackParameters(ctx context.Context, name string) (map[string]string, error)
 	ListStackResources(ctx context.Context, name string) (stackResources, error)
@@ -64,9 +92,17 @@ type API interface {
 	InspectSecret(ctx context.Context, id string) (secrets.Secret, error)
 	ListSecrets(ctx context.Context) ([]secrets.Secret, error)
 	DeleteSecret(ctx context.Context, id string, recover bool) error
-	GetLogs(ctx context.Context, name string, consumer func(container string, service string, message string), follow bool) error
+	DescribeLogStreams(ctx context.Context, group string, prefix string) ([]string, error)
+	GetLogStreamEvents(ctx context.Context, group string, stream string, query logStreamQuery) ([]logStreamEvent, *string, error)
+	StartLiveTail(ctx context.Context, group string, prefixes []string) (<-chan liveTailUpdate, error)
+	StartLogsQuery(ctx context.Context, group string, query string, start time.Time, end time.Time) (string, error)
+	GetLogsQueryResults(ctx context.Context, id string) (string, []LogQueryRow, error)
+	StopLogsQuery(ctx context.Context, id string) error
+	GetLogEvents(ctx context.Context, group string, stream string, token *string) ([]string, *string, error)
 	DescribeService(ctx context.Context, cluster string, arn string) (api.ServiceStatus, error)
 	DescribeServiceTasks(ctx context.Context, cluster string, project string, service string) ([]api.ContainerSummary, error)
+	GetTargetGroupPublishers(ctx context.Context, targetGroupArns []string) (map[string][]api.PortPublisher, error)
+	DescribeTargetHealth(ctx context.Context, targetGroupArn string) ([]*elbv2.TargetHealthDescription, error)
 	getURLWithPortMapping(ctx context.Context, targetGroupArns []string) ([]api.PortPublisher, error)
 	ListTasks(ctx context.Context, cluster string, family string) ([]string, error)
 	GetPublicIPs(ctx context.Context, interfaces ...string) (map[string]string, error)
@@ -80,4 +116,20 @@ type API interface {
 	ListFileSystems(ctx context.Context, tags map[string]string) ([]awsResource, error)
 	CreateFileSystem(ctx context.Context, tags map[string]string, options VolumeCreateOptions) (awsResource, error)
 	DeleteFileSystem(ctx context.Context, id string) error
+	ListProjectFileSystems(ctx context.Context, project string) ([]projectFileSystem, error)
+	ListMountTargets(ctx context.Context, filesystem string) ([]string, error)
+	DeleteMountTarget(ctx context.Context, id string) error
+	ListAccessPoints(ctx context.Context, filesystem string) ([]string, error)
+	DeleteAccessPoint(ctx context.Context, id string) error
+	ListProjectTaskDefinitions(ctx context.Context, project string) ([]string, error)
+	ListTemplateBuckets(ctx context.Context, project string) ([]templateBucket, error)
+	DeleteBucket(ctx context.Context, bucket string) error
+	GetCallerIdentity(ctx context.Context) (callerIdentity, error)
+	ListDeploymentRevisions(ctx context.Context, bucket string, owner string, project string) ([]DeploymentRevision, error)
+	DescribeDeploymentRevision(ctx context.Context, bucket string, owner string, project string, revision int) (DeploymentRevision, error)
+	GetDeploymentRevision(ctx context.Context, bucket string, owner string, project string, revision int) (DeploymentRevision, []byte, error)
+	PutDeploymentRevision(ctx context.Context, bucket string, owner string, region string, project string, revision DeploymentRevision, template []byte) error
+	GetPendingDeploymentRevision(ctx context.Context, bucket string, owner string, project string) (DeploymentRevision, []byte, error)
+	PutPendingDeploymentRevision(ctx context.Context, bucket string, owner string, region string, project string, revision DeploymentRevision, template []byte) error
+	DeletePendingDeploymentRevision(ctx context.Context, bucket string, owner string, project string) error
 }
@@ -7,9 +7,12 @@ package ecs
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	applicationautoscaling "github.com/aws/aws-sdk-go/service/applicationautoscaling"
 	cloudformation "github.com/aws/aws-sdk-go/service/cloudformation"
 	ecs "github.com/aws/aws-sdk-go/service/ecs"
+	elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
 	compose "github.com/docker/compose/v2/pkg/api"
 	gomock "github.com/golang/mock/gomock"
 
@@ -39,6 +42,20 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// CancelUpdateStack mocks base method
+func (m *MockAPI) CancelUpdateStack(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUpdateStack", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelUpdateStack indicates an expected call of CancelUpdateStack
+func (mr *MockAPIMockRecorder) CancelUpdateStack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUpdateStack", reflect.TypeOf((*MockAPI)(nil).CancelUpdateStack), arg0, arg1)
+}
+
 // CheckRequirements mocks base method
 func (m *MockAPI) CheckRequirements(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -68,18 +85,18 @@ func (mr *MockAPIMockRecorder) CheckVPC(arg0, arg1 interface{}) *gomock.Call {
 }
 
 // CreateChangeSet mocks base method
-func (m *MockAPI) CreateChangeSet(arg0 context.Context, arg1, arg2 string, arg3 []byte) (string, error) {
+func (m *MockAPI) CreateChangeSet(arg0 context.Context, arg1, arg2, arg3 string, arg4 []byte) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateChangeSet", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "CreateChangeSet", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateChangeSet indicates an expected call of CreateChangeSet
-func (mr *MockAPIMockRecorder) CreateChangeSet(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockAPIMockRecorder) CreateChangeSet(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChangeSet", reflect.TypeOf((*MockAPI)(nil).CreateChangeSet), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChangeSet", reflect.TypeOf((*MockAPI)(nil).CreateChangeSet), arg0, arg1, arg2, arg3, arg4)
 }
 
 // CreateCluster mocks base method
@@ -141,6 +158,20 @@ func (mr *MockAPIMockRecorder) CreateStack(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStack", reflect.TypeOf((*MockAPI)(nil).CreateStack), arg0, arg1, arg2, arg3)
 }
 
+// DeleteAccessPoint mocks base method
+func (m *MockAPI) DeleteAccessPoint(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccessPoint", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccessPoint indicates an expected call of DeleteAccessPoint
+func (mr *MockAPIMockRecorder) DeleteAccessPoint(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccessPoint", reflect.TypeOf((*MockAPI)(nil).DeleteAccessPoint), arg0, arg1)
+}
+
 // DeleteAutoscalingGroup mocks base method
 func (m *MockAPI) DeleteAutoscalingGroup(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -155,6 +186,20 @@ func (mr *MockAPIMockRecorder) DeleteAutoscalingGroup(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAutoscalingGroup", reflect.TypeOf((*MockAPI)(nil).DeleteAutoscalingGroup), arg0, arg1)
 }
 
+// DeleteBucket mocks base method
+func (m *MockAPI) DeleteBucket(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBucket", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBucket indicates an expected call of DeleteBucket
+func (mr *MockAPIMockRecorder) DeleteBucket(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBucket", reflect.TypeOf((*MockAPI)(nil).DeleteBucket), arg0, arg1)
+}
+
 // DeleteCapacityProvider mocks base method
 func (m *MockAPI) DeleteCapacityProvider(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -183,6 +228,34 @@ func (mr *MockAPIMockRecorder) DeleteFileSystem(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileSystem", reflect.TypeOf((*MockAPI)(nil).DeleteFileSystem), arg0, arg1)
 }
 
+// DeleteMountTarget mocks base method
+func (m *MockAPI) DeleteMountTarget(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMountTarget", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMountTarget indicates an expected call of DeleteMountTarget
+func (mr *MockAPIMockRecorder) DeleteMountTarget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMountTarget", reflect.TypeOf((*MockAPI)(nil).DeleteMountTarget), arg0, arg1)
+}
+
+// DeletePendingDeploymentRevision mocks base method
+func (m *MockAPI) DeletePendingDeploymentRevision(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePendingDeploymentRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePendingDeploymentRevision indicates an expected call of DeletePendingDeploymentRevision
+func (mr *MockAPIMockRecorder) DeletePendingDeploymentRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingDeploymentRevision", reflect.TypeOf((*MockAPI)(nil).DeletePendingDeploymentRevision), arg0, arg1, arg2, arg3)
+}
+
 // DeleteSecret mocks base method
 func (m *MockAPI) DeleteSecret(arg0 context.Context, arg1 string, arg2 bool) error {
 	m.ctrl.T.Helper()
@@ -211,6 +284,65 @@ func (mr *MockAPIMockRecorder) DeleteStack(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStack", reflect.TypeOf((*MockAPI)(nil).DeleteStack), arg0, arg1)
 }
 
+// DeregisterTaskDefinition mocks base method
+func (m *MockAPI) DeregisterTaskDefinition(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeregisterTaskDefinition", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeregisterTaskDefinition indicates an expected call of DeregisterTaskDefinition
+func (mr *MockAPIMockRecorder) DeregisterTaskDefinition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterTaskDefinition", reflect.TypeOf((*MockAPI)(nil).DeregisterTaskDefinition), arg0, arg1)
+}
+
+// DescribeChangeSet mocks base method
+func (m *MockAPI) DescribeChangeSet(arg0 context.Context, arg1, arg2 string) (changeSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeChangeSet", arg0, arg1, arg2)
+	ret0, _ := ret[0].(changeSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeChangeSet indicates an expected call of DescribeChangeSet
+func (mr *MockAPIMockRecorder) DescribeChangeSet(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeChangeSet", reflect.TypeOf((*MockAPI)(nil).DescribeChangeSet), arg0, arg1, arg2)
+}
+
+// DescribeDeploymentRevision mocks base method
+func (m *MockAPI) DescribeDeploymentRevision(arg0 context.Context, arg1, arg2, arg3 string, arg4 int) (DeploymentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeDeploymentRevision", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(DeploymentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeDeploymentRevision indicates an expected call of DescribeDeploymentRevision
+func (mr *MockAPIMockRecorder) DescribeDeploymentRevision(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeDeploymentRevision", reflect.TypeOf((*MockAPI)(nil).DescribeDeploymentRevision), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DescribeLogStreams mocks base method
+func (m *MockAPI) DescribeLogStreams(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeLogStreams", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeLogStreams indicates an expected call of DescribeLogStreams
+func (mr *MockAPIMockRecorder) DescribeLogStreams(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLogStreams", reflect.TypeOf((*MockAPI)(nil).DescribeLogStreams), arg0, arg1, arg2)
+}
+
 // DescribeService mocks base method
 func (m *MockAPI) DescribeService(arg0 context.Context, arg1, arg2 string) (compose.ServiceStatus, error) {
 	m.ctrl.T.Helper()
@@ -241,6 +373,21 @@ func (mr *MockAPIMockRecorder) DescribeServiceTasks(arg0, arg1, arg2, arg3 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeServiceTasks", reflect.TypeOf((*MockAPI)(nil).DescribeServiceTasks), arg0, arg1, arg2, arg3)
 }
 
+// DescribeServices mocks base method
+func (m *MockAPI) DescribeServices(arg0 context.Context, arg1 string, arg2 []string) ([]*ecs.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeServices", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*ecs.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeServices indicates an expected call of DescribeServices
+func (mr *MockAPIMockRecorder) DescribeServices(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeServices", reflect.TypeOf((*MockAPI)(nil).DescribeServices), arg0, arg1, arg2)
+}
+
 // DescribeStackEvents mocks base method
 func (m *MockAPI) DescribeStackEvents(arg0 context.Context, arg1 string) ([]*cloudformation.StackEvent, error) {
 	m.ctrl.T.Helper()
@@ -256,6 +403,96 @@ func (mr *MockAPIMockRecorder) DescribeStackEvents(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeStackEvents", reflect.TypeOf((*MockAPI)(nil).DescribeStackEvents), arg0, arg1)
 }
 
+// DescribeTargetHealth mocks base method
+func (m *MockAPI) DescribeTargetHealth(arg0 context.Context, arg1 string) ([]*elbv2.TargetHealthDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTargetHealth", arg0, arg1)
+	ret0, _ := ret[0].([]*elbv2.TargetHealthDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTargetHealth indicates an expected call of DescribeTargetHealth
+func (mr *MockAPIMockRecorder) DescribeTargetHealth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTargetHealth", reflect.TypeOf((*MockAPI)(nil).DescribeTargetHealth), arg0, arg1)
+}
+
+// DescribeTask mocks base method
+func (m *MockAPI) DescribeTask(arg0 context.Context, arg1, arg2 string) (*ecs.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTask", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*ecs.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeTask indicates an expected call of DescribeTask
+func (mr *MockAPIMockRecorder) DescribeTask(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTask", reflect.TypeOf((*MockAPI)(nil).DescribeTask), arg0, arg1, arg2)
+}
+
+// DescribeTaskDefinition mocks base method
+func (m *MockAPI) DescribeTaskDefinition(arg0 context.Context, arg1 string) (*ecs.TaskDefinition, []*ecs.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeTaskDefinition", arg0, arg1)
+	ret0, _ := ret[0].(*ecs.TaskDefinition)
+	ret1, _ := ret[1].([]*ecs.Tag)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DescribeTaskDefinition indicates an expected call of DescribeTaskDefinition
+func (mr *MockAPIMockRecorder) DescribeTaskDefinition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeTaskDefinition", reflect.TypeOf((*MockAPI)(nil).DescribeTaskDefinition), arg0, arg1)
+}
+
+// ExecuteCommand mocks base method
+func (m *MockAPI) ExecuteCommand(arg0 context.Context, arg1, arg2, arg3, arg4 string) (*ecs.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteCommand", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*ecs.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteCommand indicates an expected call of ExecuteCommand
+func (mr *MockAPIMockRecorder) ExecuteCommand(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteCommand", reflect.TypeOf((*MockAPI)(nil).ExecuteCommand), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ForceNewDeployment mocks base method
+func (m *MockAPI) ForceNewDeployment(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceNewDeployment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceNewDeployment indicates an expected call of ForceNewDeployment
+func (mr *MockAPIMockRecorder) ForceNewDeployment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceNewDeployment", reflect.TypeOf((*MockAPI)(nil).ForceNewDeployment), arg0, arg1, arg2)
+}
+
+// GetCallerIdentity mocks base method
+func (m *MockAPI) GetCallerIdentity(arg0 context.Context) (callerIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCallerIdentity", arg0)
+	ret0, _ := ret[0].(callerIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCallerIdentity indicates an expected call of GetCallerIdentity
+func (mr *MockAPIMockRecorder) GetCallerIdentity(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallerIdentity", reflect.TypeOf((*MockAPI)(nil).GetCallerIdentity), arg0)
+}
+
 // GetDefaultVPC mocks base method
 func (m *MockAPI) GetDefaultVPC(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -271,6 +508,22 @@ func (mr *MockAPIMockRecorder) GetDefaultVPC(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultVPC", reflect.TypeOf((*MockAPI)(nil).GetDefaultVPC), arg0)
 }
 
+// GetDeploymentRevision mocks base method
+func (m *MockAPI) GetDeploymentRevision(arg0 context.Context, arg1, arg2, arg3 string, arg4 int) (DeploymentRevision, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeploymentRevision", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(DeploymentRevision)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDeploymentRevision indicates an expected call of GetDeploymentRevision
+func (mr *MockAPIMockRecorder) GetDeploymentRevision(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeploymentRevision", reflect.TypeOf((*MockAPI)(nil).GetDeploymentRevision), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetLoadBalancerURL mocks base method
 func (m *MockAPI) GetLoadBalancerURL(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -286,18 +539,52 @@ func (mr *MockAPIMockRecorder) GetLoadBalancerURL(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerURL", reflect.TypeOf((*MockAPI)(nil).GetLoadBalancerURL), arg0, arg1)
 }
 
-// GetLogs mocks base method
-func (m *MockAPI) GetLogs(arg0 context.Context, arg1 string, arg2 func(string, string, string), arg3 bool) error {
+// GetLogEvents mocks base method
+func (m *MockAPI) GetLogEvents(arg0 context.Context, arg1, arg2 string, arg3 *string) ([]string, *string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLogs", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetLogEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetLogs indicates an expected call of GetLogs
-func (mr *MockAPIMockRecorder) GetLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+// GetLogEvents indicates an expected call of GetLogEvents
+func (mr *MockAPIMockRecorder) GetLogEvents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockAPI)(nil).GetLogs), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogEvents", reflect.TypeOf((*MockAPI)(nil).GetLogEvents), arg0, arg1, arg2, arg3)
+}
+
+// GetLogStreamEvents mocks base method
+func (m *MockAPI) GetLogStreamEvents(arg0 context.Context, arg1, arg2 string, arg3 logStreamQuery) ([]logStreamEvent, *string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogStreamEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]logStreamEvent)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLogStreamEvents indicates an expected call of GetLogStreamEvents
+func (mr *MockAPIMockRecorder) GetLogStreamEvents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogStreamEvents", reflect.TypeOf((*MockAPI)(nil).GetLogStreamEvents), arg0, arg1, arg2, arg3)
+}
+
+// GetLogsQueryResults mocks base method
+func (m *MockAPI) GetLogsQueryResults(arg0 context.Context, arg1 string) (string, []LogQueryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogsQueryResults", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]LogQueryRow)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLogsQueryResults indicates an expected call of GetLogsQueryResults
+func (mr *MockAPIMockRecorder) GetLogsQueryResults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogsQueryResults", reflect.TypeOf((*MockAPI)(nil).GetLogsQueryResults), arg0, arg1)
 }
 
 // GetParameter mocks base method
@@ -315,6 +602,22 @@ func (mr *MockAPIMockRecorder) GetParameter(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParameter", reflect.TypeOf((*MockAPI)(nil).GetParameter), arg0, arg1)
 }
 
+// GetPendingDeploymentRevision mocks base method
+func (m *MockAPI) GetPendingDeploymentRevision(arg0 context.Context, arg1, arg2, arg3 string) (DeploymentRevision, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingDeploymentRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(DeploymentRevision)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPendingDeploymentRevision indicates an expected call of GetPendingDeploymentRevision
+func (mr *MockAPIMockRecorder) GetPendingDeploymentRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingDeploymentRevision", reflect.TypeOf((*MockAPI)(nil).GetPendingDeploymentRevision), arg0, arg1, arg2, arg3)
+}
+
 // GetPublicIPs mocks base method
 func (m *MockAPI) GetPublicIPs(arg0 context.Context, arg1 ...string) (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -350,6 +653,51 @@ func (mr *MockAPIMockRecorder) GetRoleArn(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleArn", reflect.TypeOf((*MockAPI)(nil).GetRoleArn), arg0, arg1)
 }
 
+// GetServiceMetrics mocks base method
+func (m *MockAPI) GetServiceMetrics(arg0 context.Context, arg1, arg2 string) (serviceMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceMetrics", arg0, arg1, arg2)
+	ret0, _ := ret[0].(serviceMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceMetrics indicates an expected call of GetServiceMetrics
+func (mr *MockAPIMockRecorder) GetServiceMetrics(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceMetrics", reflect.TypeOf((*MockAPI)(nil).GetServiceMetrics), arg0, arg1, arg2)
+}
+
+// GetServiceScalableTarget mocks base method
+func (m *MockAPI) GetServiceScalableTarget(arg0 context.Context, arg1 string) (*applicationautoscaling.ScalableTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceScalableTarget", arg0, arg1)
+	ret0, _ := ret[0].(*applicationautoscaling.ScalableTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceScalableTarget indicates an expected call of GetServiceScalableTarget
+func (mr *MockAPIMockRecorder) GetServiceScalableTarget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceScalableTarget", reflect.TypeOf((*MockAPI)(nil).GetServiceScalableTarget), arg0, arg1)
+}
+
+// GetServiceTags mocks base method
+func (m *MockAPI) GetServiceTags(arg0 context.Context, arg1 string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceTags", arg0, arg1)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceTags indicates an expected call of GetServiceTags
+func (mr *MockAPIMockRecorder) GetServiceTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceTags", reflect.TypeOf((*MockAPI)(nil).GetServiceTags), arg0, arg1)
+}
+
 // GetServiceTaskDefinition mocks base method
 func (m *MockAPI) GetServiceTaskDefinition(arg0 context.Context, arg1 string, arg2 []string) (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -410,6 +758,36 @@ func (mr *MockAPIMockRecorder) GetStackID(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStackID", reflect.TypeOf((*MockAPI)(nil).GetStackID), arg0, arg1)
 }
 
+// GetStackMetadataClusterID mocks base method
+func (m *MockAPI) GetStackMetadataClusterID(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStackMetadataClusterID", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStackMetadataClusterID indicates an expected call of GetStackMetadataClusterID
+func (mr *MockAPIMockRecorder) GetStackMetadataClusterID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStackMetadataClusterID", reflect.TypeOf((*MockAPI)(nil).GetStackMetadataClusterID), arg0, arg1)
+}
+
+// GetStackStatus mocks base method
+func (m *MockAPI) GetStackStatus(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStackStatus", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStackStatus indicates an expected call of GetStackStatus
+func (mr *MockAPIMockRecorder) GetStackStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStackStatus", reflect.TypeOf((*MockAPI)(nil).GetStackStatus), arg0, arg1)
+}
+
 // GetSubNets mocks base method
 func (m *MockAPI) GetSubNets(arg0 context.Context, arg1 string) ([]awsResource, error) {
 	m.ctrl.T.Helper()
@@ -425,6 +803,21 @@ func (mr *MockAPIMockRecorder) GetSubNets(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubNets", reflect.TypeOf((*MockAPI)(nil).GetSubNets), arg0, arg1)
 }
 
+// GetTargetGroupPublishers mocks base method
+func (m *MockAPI) GetTargetGroupPublishers(arg0 context.Context, arg1 []string) (map[string][]compose.PortPublisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTargetGroupPublishers", arg0, arg1)
+	ret0, _ := ret[0].(map[string][]compose.PortPublisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTargetGroupPublishers indicates an expected call of GetTargetGroupPublishers
+func (mr *MockAPIMockRecorder) GetTargetGroupPublishers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTargetGroupPublishers", reflect.TypeOf((*MockAPI)(nil).GetTargetGroupPublishers), arg0, arg1)
+}
+
 // GetTaskStoppedReason mocks base method
 func (m *MockAPI) GetTaskStoppedReason(arg0 context.Context, arg1, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -440,6 +833,21 @@ func (mr *MockAPIMockRecorder) GetTaskStoppedReason(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStoppedReason", reflect.TypeOf((*MockAPI)(nil).GetTaskStoppedReason), arg0, arg1, arg2)
 }
 
+// GetTemplate mocks base method
+func (m *MockAPI) GetTemplate(arg0 context.Context, arg1, arg2 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate
+func (mr *MockAPIMockRecorder) GetTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockAPI)(nil).GetTemplate), arg0, arg1, arg2)
+}
+
 // InspectSecret mocks base method
 func (m *MockAPI) InspectSecret(arg0 context.Context, arg1 string) (secrets.Secret, error) {
 	m.ctrl.T.Helper()
@@ -470,6 +878,36 @@ func (mr *MockAPIMockRecorder) IsPublicSubnet(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPublicSubnet", reflect.TypeOf((*MockAPI)(nil).IsPublicSubnet), arg0, arg1)
 }
 
+// ListAccessPoints mocks base method
+func (m *MockAPI) ListAccessPoints(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccessPoints", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccessPoints indicates an expected call of ListAccessPoints
+func (mr *MockAPIMockRecorder) ListAccessPoints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccessPoints", reflect.TypeOf((*MockAPI)(nil).ListAccessPoints), arg0, arg1)
+}
+
+// ListDeploymentRevisions mocks base method
+func (m *MockAPI) ListDeploymentRevisions(arg0 context.Context, arg1, arg2, arg3 string) ([]DeploymentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeploymentRevisions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]DeploymentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeploymentRevisions indicates an expected call of ListDeploymentRevisions
+func (mr *MockAPIMockRecorder) ListDeploymentRevisions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploymentRevisions", reflect.TypeOf((*MockAPI)(nil).ListDeploymentRevisions), arg0, arg1, arg2, arg3)
+}
+
 // ListFileSystems mocks base method
 func (m *MockAPI) ListFileSystems(arg0 context.Context, arg1 map[string]string) ([]awsResource, error) {
 	m.ctrl.T.Helper()
@@ -485,6 +923,51 @@ func (mr *MockAPIMockRecorder) ListFileSystems(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileSystems", reflect.TypeOf((*MockAPI)(nil).ListFileSystems), arg0, arg1)
 }
 
+// ListMountTargets mocks base method
+func (m *MockAPI) ListMountTargets(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMountTargets", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMountTargets indicates an expected call of ListMountTargets
+func (mr *MockAPIMockRecorder) ListMountTargets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMountTargets", reflect.TypeOf((*MockAPI)(nil).ListMountTargets), arg0, arg1)
+}
+
+// ListProjectFileSystems mocks base method
+func (m *MockAPI) ListProjectFileSystems(arg0 context.Context, arg1 string) ([]projectFileSystem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjectFileSystems", arg0, arg1)
+	ret0, _ := ret[0].([]projectFileSystem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjectFileSystems indicates an expected call of ListProjectFileSystems
+func (mr *MockAPIMockRecorder) ListProjectFileSystems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectFileSystems", reflect.TypeOf((*MockAPI)(nil).ListProjectFileSystems), arg0, arg1)
+}
+
+// ListProjectTaskDefinitions mocks base method
+func (m *MockAPI) ListProjectTaskDefinitions(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjectTaskDefinitions", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjectTaskDefinitions indicates an expected call of ListProjectTaskDefinitions
+func (mr *MockAPIMockRecorder) ListProjectTaskDefinitions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjectTaskDefinitions", reflect.TypeOf((*MockAPI)(nil).ListProjectTaskDefinitions), arg0, arg1)
+}
+
 // ListSecrets mocks base method
 func (m *MockAPI) ListSecrets(arg0 context.Context) ([]secrets.Secret, error) {
 	m.ctrl.T.Helper()
@@ -546,18 +1029,18 @@ func (mr *MockAPIMockRecorder) ListStackServices(arg0, arg1 interface{}) *gomock
 }
 
 // ListStacks mocks base method
-func (m *MockAPI) ListStacks(arg0 context.Context) ([]compose.Stack, error) {
+func (m *MockAPI) ListStacks(arg0 context.Context, arg1 bool) ([]projectStack, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListStacks", arg0)
-	ret0, _ := ret[0].([]compose.Stack)
+	ret := m.ctrl.Call(m, "ListStacks", arg0, arg1)
+	ret0, _ := ret[0].([]projectStack)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListStacks indicates an expected call of ListStacks
-func (mr *MockAPIMockRecorder) ListStacks(arg0 interface{}) *gomock.Call {
+func (mr *MockAPIMockRecorder) ListStacks(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStacks", reflect.TypeOf((*MockAPI)(nil).ListStacks), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStacks", reflect.TypeOf((*MockAPI)(nil).ListStacks), arg0, arg1)
 }
 
 // ListTasks mocks base method
@@ -575,6 +1058,64 @@ func (mr *MockAPIMockRecorder) ListTasks(arg0, arg1, arg2 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockAPI)(nil).ListTasks), arg0, arg1, arg2)
 }
 
+// ListTemplateBuckets mocks base method
+func (m *MockAPI) ListTemplateBuckets(arg0 context.Context, arg1 string) ([]templateBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplateBuckets", arg0, arg1)
+	ret0, _ := ret[0].([]templateBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplateBuckets indicates an expected call of ListTemplateBuckets
+func (mr *MockAPIMockRecorder) ListTemplateBuckets(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplateBuckets", reflect.TypeOf((*MockAPI)(nil).ListTemplateBuckets), arg0, arg1)
+}
+
+// PutDeploymentRevision mocks base method
+func (m *MockAPI) PutDeploymentRevision(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 DeploymentRevision, arg6 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutDeploymentRevision", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutDeploymentRevision indicates an expected call of PutDeploymentRevision
+func (mr *MockAPIMockRecorder) PutDeploymentRevision(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutDeploymentRevision", reflect.TypeOf((*MockAPI)(nil).PutDeploymentRevision), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// PutPendingDeploymentRevision mocks base method
+func (m *MockAPI) PutPendingDeploymentRevision(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 DeploymentRevision, arg6 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutPendingDeploymentRevision", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutPendingDeploymentRevision indicates an expected call of PutPendingDeploymentRevision
+func (mr *MockAPIMockRecorder) PutPendingDeploymentRevision(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingDeploymentRevision", reflect.TypeOf((*MockAPI)(nil).PutPendingDeploymentRevision), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// RegisterTaskDefinition mocks base method
+func (m *MockAPI) RegisterTaskDefinition(arg0 context.Context, arg1 *ecs.RegisterTaskDefinitionInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterTaskDefinition", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterTaskDefinition indicates an expected call of RegisterTaskDefinition
+func (mr *MockAPIMockRecorder) RegisterTaskDefinition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterTaskDefinition", reflect.TypeOf((*MockAPI)(nil).RegisterTaskDefinition), arg0, arg1)
+}
+
 // ResolveCluster mocks base method
 func (m *MockAPI) ResolveCluster(arg0 context.Context, arg1 string) (awsResource, error) {
 	m.ctrl.T.Helper()
@@ -623,6 +1164,35 @@ func (mr *MockAPIMockRecorder) ResolveLoadBalancer(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLoadBalancer", reflect.TypeOf((*MockAPI)(nil).ResolveLoadBalancer), arg0, arg1)
 }
 
+// RunTask mocks base method
+func (m *MockAPI) RunTask(arg0 context.Context, arg1 *ecs.RunTaskInput) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunTask", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunTask indicates an expected call of RunTask
+func (mr *MockAPIMockRecorder) RunTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunTask", reflect.TypeOf((*MockAPI)(nil).RunTask), arg0, arg1)
+}
+
+// ScaleService mocks base method
+func (m *MockAPI) ScaleService(arg0 context.Context, arg1, arg2 string, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScaleService", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScaleService indicates an expected call of ScaleService
+func (mr *MockAPIMockRecorder) ScaleService(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScaleService", reflect.TypeOf((*MockAPI)(nil).ScaleService), arg0, arg1, arg2, arg3)
+}
+
 // SecurityGroupExists mocks base method
 func (m *MockAPI) SecurityGroupExists(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -653,6 +1223,106 @@ func (mr *MockAPIMockRecorder) StackExists(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StackExists", reflect.TypeOf((*MockAPI)(nil).StackExists), arg0, arg1)
 }
 
+// StartLiveTail mocks base method
+func (m *MockAPI) StartLiveTail(arg0 context.Context, arg1 string, arg2 []string) (<-chan liveTailUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartLiveTail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan liveTailUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartLiveTail indicates an expected call of StartLiveTail
+func (mr *MockAPIMockRecorder) StartLiveTail(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartLiveTail", reflect.TypeOf((*MockAPI)(nil).StartLiveTail), arg0, arg1, arg2)
+}
+
+// StartLogsQuery mocks base method
+func (m *MockAPI) StartLogsQuery(arg0 context.Context, arg1, arg2 string, arg3, arg4 time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartLogsQuery", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartLogsQuery indicates an expected call of StartLogsQuery
+func (mr *MockAPIMockRecorder) StartLogsQuery(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartLogsQuery", reflect.TypeOf((*MockAPI)(nil).StartLogsQuery), arg0, arg1, arg2, arg3, arg4)
+}
+
+// StopLogsQuery mocks base method
+func (m *MockAPI) StopLogsQuery(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopLogsQuery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopLogsQuery indicates an expected call of StopLogsQuery
+func (mr *MockAPIMockRecorder) StopLogsQuery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopLogsQuery", reflect.TypeOf((*MockAPI)(nil).StopLogsQuery), arg0, arg1)
+}
+
+// StopTask mocks base method
+func (m *MockAPI) StopTask(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTask", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopTask indicates an expected call of StopTask
+func (mr *MockAPIMockRecorder) StopTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTask", reflect.TypeOf((*MockAPI)(nil).StopTask), arg0, arg1, arg2, arg3)
+}
+
+// TagService mocks base method
+func (m *MockAPI) TagService(arg0 context.Context, arg1 string, arg2 map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagService", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TagService indicates an expected call of TagService
+func (mr *MockAPIMockRecorder) TagService(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagService", reflect.TypeOf((*MockAPI)(nil).TagService), arg0, arg1, arg2)
+}
+
+// UntagService mocks base method
+func (m *MockAPI) UntagService(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntagService", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UntagService indicates an expected call of UntagService
+func (mr *MockAPIMockRecorder) UntagService(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntagService", reflect.TypeOf((*MockAPI)(nil).UntagService), arg0, arg1, arg2)
+}
+
+// UpdateServiceScalableTarget mocks base method
+func (m *MockAPI) UpdateServiceScalableTarget(arg0 context.Context, arg1 string, arg2 int64, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateServiceScalableTarget", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateServiceScalableTarget indicates an expected call of UpdateServiceScalableTarget
+func (mr *MockAPIMockRecorder) UpdateServiceScalableTarget(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateServiceScalableTarget", reflect.TypeOf((*MockAPI)(nil).UpdateServiceScalableTarget), arg0, arg1, arg2, arg3)
+}
+
 // UpdateStack mocks base method
 func (m *MockAPI) UpdateStack(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -667,6 +1337,20 @@ func (mr *MockAPIMockRecorder) UpdateStack(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStack", reflect.TypeOf((*MockAPI)(nil).UpdateStack), arg0, arg1)
 }
 
+// WaitServicesStable mocks base method
+func (m *MockAPI) WaitServicesStable(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitServicesStable", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitServicesStable indicates an expected call of WaitServicesStable
+func (mr *MockAPIMockRecorder) WaitServicesStable(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitServicesStable", reflect.TypeOf((*MockAPI)(nil).WaitServicesStable), arg0, arg1, arg2)
+}
+
 // WaitStackComplete mocks base method
 func (m *MockAPI) WaitStackComplete(arg0 context.Context, arg1 string, arg2 int) error {
 	m.ctrl.T.Helper()
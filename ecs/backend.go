@@ -23,7 +23,9 @@ import (
 	"github.com/docker/compose-ecs/api/volumes"
 
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/docker/cli/cli/streams"
 	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/prompt"
 )
 
 func NewComposeECS() (*ComposeECS, error) {
@@ -39,12 +41,18 @@ func NewComposeECS() (*ComposeECS, error) {
 	return &ComposeECS{
 		Region: *sess.Config.Region,
 		aws:    sdk,
+		prompt: prompt.NewPrompt(streams.NewIn(os.Stdin), streams.NewOut(os.Stdout)),
 	}, nil
 }
 
 type ComposeECS struct {
-	Region string
-	aws    API
+	Region        string
+	EventsOptions EventsOptions
+	KillOptions   KillOptions
+	UpOptions     UpOptions
+	DownOptions   DownOptions
+	aws           API
+	prompt        prompt.UI
 }
 
 func (b *ComposeECS) ComposeService() api.Service {
@@ -0,0 +1,296 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ecsapi "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/awslabs/goformation/v4/cloudformation"
+	"github.com/awslabs/goformation/v4/cloudformation/ecs"
+	"github.com/awslabs/goformation/v4/cloudformation/elasticloadbalancingv2"
+	"github.com/compose-spec/compose-go/types"
+)
+
+const (
+	blueGreenTransform = "AWS::CodeDeployBlueGreen"
+	blueGreenHookName  = "CodeDeployBlueGreenHook"
+	blueGreenHookType  = "AWS::CodeDeploy::BlueGreen"
+	// blueGreenTestPort is the default test listener port, as set by ECS console
+	blueGreenTestPort = 8080
+
+	trafficShiftAllAtOnce = "all-at-once"
+	trafficShiftCanary    = "canary"
+	trafficShiftLinear    = "linear"
+
+	defaultTrafficShiftPercentage = 10
+	defaultTrafficShiftInterval   = 5
+)
+
+// blueGreenConfig is the x-aws-blue_green configuration of a service deployed by CodeDeploy
+type blueGreenConfig struct {
+	// TrafficShift is the strategy to shift traffic from blue to green tasks: all-at-once, canary or linear
+	TrafficShift string `json:"traffic_shift,omitempty"`
+	// Percentage of traffic shifted by the first (canary) or each (linear) step
+	Percentage int `json:"percentage,omitempty"`
+	// Interval is the time, in minutes, between steps
+	Interval int `json:"interval,omitempty"`
+	TestPort int `json:"test_port,omitempty"`
+}
+
+func (c blueGreenConfig) sameTrafficShift(other blueGreenConfig) bool {
+	return c.TrafficShift == other.TrafficShift && c.Percentage == other.Percentage && c.Interval == other.Interval
+}
+
+func (c blueGreenConfig) trafficRouting() blueGreenTrafficRoutingConfig {
+	shift := &blueGreenTrafficShift{
+		StepPercentage: c.Percentage,
+		BakeTimeMins:   c.Interval,
+	}
+	switch c.TrafficShift {
+	case trafficShiftCanary:
+		return blueGreenTrafficRoutingConfig{Type: "TimeBasedCanary", TimeBasedCanary: shift}
+	case trafficShiftLinear:
+		return blueGreenTrafficRoutingConfig{Type: "TimeBasedLinear", TimeBasedLinear: shift}
+	default:
+		return blueGreenTrafficRoutingConfig{Type: "AllAtOnce"}
+	}
+}
+
+// blueGreenDeployment returns the blue/green deployment configuration of service, or nil when it is deployed by rolling updates
+func blueGreenDeployment(service types.ServiceConfig) (*blueGreenConfig, error) {
+	if service.Deploy == nil || service.Deploy.UpdateConfig == nil {
+		return nil, nil
+	}
+	updateConfig := service.Deploy.UpdateConfig
+	v, ok := updateConfig.Extensions[extensionBlueGreen]
+	if !ok {
+		return nil, nil
+	}
+	marshalled, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var config blueGreenConfig
+	if err := json.Unmarshal(marshalled, &config); err != nil {
+		return nil, fmt.Errorf("service %s: invalid %s: %s", service.Name, extensionBlueGreen, err)
+	}
+
+	for _, x := range []string{extensionCircuitBreaker, extensionAlarms} {
+		if _, ok := updateConfig.Extensions[x]; ok {
+			return nil, fmt.Errorf("service %s: %s can't be set with %s, as deployments are run by CodeDeploy", service.Name, x, extensionBlueGreen)
+		}
+	}
+	if updateConfig.FailureAction != "" && updateConfig.FailureAction != "rollback" {
+		return nil, fmt.Errorf("service %s: %s deployments are always rolled back on failure", service.Name, extensionBlueGreen)
+	}
+	if len(service.Ports) != 1 {
+		return nil, fmt.Errorf("service %s: %s requires a single published port, as CodeDeploy shifts traffic of a single listener", service.Name, extensionBlueGreen)
+	}
+
+	switch config.TrafficShift {
+	case "", trafficShiftAllAtOnce:
+		if config.Percentage != 0 || config.Interval != 0 {
+			return nil, fmt.Errorf("service %s: percentage and interval only apply to %s and %s traffic shift", service.Name, trafficShiftCanary, trafficShiftLinear)
+		}
+		config.TrafficShift = trafficShiftAllAtOnce
+	case trafficShiftCanary, trafficShiftLinear:
+		if config.Percentage == 0 {
+			config.Percentage = defaultTrafficShiftPercentage
+		}
+		if config.Interval == 0 {
+			config.Interval = defaultTrafficShiftInterval
+		}
+		if config.Percentage < 1 || config.Percentage > 99 {
+			return nil, fmt.Errorf("service %s: traffic shift percentage must be between 1 and 99, got %d", service.Name, config.Percentage)
+		}
+		if config.Interval < 1 {
+			return nil, fmt.Errorf("service %s: traffic shift interval must be a positive number of minutes, got %d", service.Name, config.Interval)
+		}
+	default:
+		return nil, fmt.Errorf("service %s: unsupported traffic_shift %q, must be one of %s, %s or %s", service.Name, config.TrafficShift,
+			trafficShiftAllAtOnce, trafficShiftCanary, trafficShiftLinear)
+	}
+
+	if config.TestPort == 0 {
+		config.TestPort = blueGreenTestPort
+	}
+	return &config, nil
+}
+
+// createBlueGreenTargets declares the green target group new tasks are registered with by a blue/green deployment, and the listener to
+// test them before traffic is shifted. Both are copies of the blue ones, and the test listener forwards to the blue target group until
+// a deployment starts
+func (b *ComposeECS) createBlueGreenTargets(service types.ServiceConfig, port types.ServicePortConfig, testPort int, template *cloudformation.Template, resources awsResources) string {
+	blue := template.Resources[targetGroupResourceName(service.Name, port)].(*elasticloadbalancingv2.TargetGroup)
+	green := *blue
+	template.Resources[greenTargetGroupResourceName(service.Name, port)] = &green
+
+	listener := *template.Resources[listenerResourceName(service.Name, port)].(*elasticloadbalancingv2.Listener)
+	listener.Port = testPort
+	testListener := testListenerResourceName(service.Name, port)
+	template.Resources[testListener] = &listener
+
+	for net := range service.Networks {
+		b.createIngress(service, net, types.ServicePortConfig{Target: uint32(testPort), Protocol: port.Protocol}, template, resources)
+	}
+	return testListener
+}
+
+// createTaskSet moves tasks configuration from service to a task set, as a service with an EXTERNAL deployment controller only sets
+// how many tasks run. This task set is the primary one, which the hook replaces by a green task set on deployment
+func (b *ComposeECS) createTaskSet(service types.ServiceConfig, template *cloudformation.Template, serviceResource *ecsService) {
+	s := &serviceResource.Service
+	var loadBalancers []ecs.TaskSet_LoadBalancer
+	for _, lb := range s.LoadBalancers {
+		loadBalancers = append(loadBalancers, ecs.TaskSet_LoadBalancer{
+			ContainerName:  lb.ContainerName,
+			ContainerPort:  lb.ContainerPort,
+			TargetGroupArn: lb.TargetGroupArn,
+		})
+	}
+	var serviceRegistries []ecs.TaskSet_ServiceRegistry
+	for _, r := range s.ServiceRegistries {
+		serviceRegistries = append(serviceRegistries, ecs.TaskSet_ServiceRegistry{
+			RegistryArn: r.RegistryArn,
+		})
+	}
+	vpcConfiguration := s.NetworkConfiguration.AwsvpcConfiguration
+
+	taskSet := taskSetResourceName(service.Name)
+	template.Resources[taskSet] = &ecs.TaskSet{
+		Cluster:       s.Cluster,
+		LaunchType:    s.LaunchType,
+		LoadBalancers: loadBalancers,
+		NetworkConfiguration: &ecs.TaskSet_NetworkConfiguration{
+			AwsVpcConfiguration: &ecs.TaskSet_AwsVpcConfiguration{
+				AssignPublicIp: vpcConfiguration.AssignPublicIp,
+				SecurityGroups: vpcConfiguration.SecurityGroups,
+				Subnets:        vpcConfiguration.Subnets,
+			},
+		},
+		PlatformVersion: s.PlatformVersion,
+		Scale: &ecs.TaskSet_Scale{
+			Unit:  ecsapi.ScaleUnitPercent,
+			Value: 100,
+		},
+		Service:           cloudformation.Ref(serviceResourceName(service.Name)),
+		ServiceRegistries: serviceRegistries,
+		TaskDefinition:    s.TaskDefinition,
+	}
+	template.Resources[fmt.Sprintf("%sPrimaryTaskSet", normalizeResourceName(service.Name))] = &ecs.PrimaryTaskSet{
+		Cluster:   s.Cluster,
+		Service:   cloudformation.Ref(serviceResourceName(service.Name)),
+		TaskSetId: cloudformation.GetAtt(taskSet, "Id"),
+	}
+
+	s.DeploymentController = &ecs.Service_DeploymentController{
+		Type: ecsapi.DeploymentControllerTypeExternal,
+	}
+	s.DeploymentConfiguration = nil
+	s.LaunchType = ""
+	s.LoadBalancers = nil
+	s.NetworkConfiguration = nil
+	s.PlatformVersion = ""
+	s.ServiceRegistries = nil
+	s.TaskDefinition = ""
+	serviceResource.DeploymentFailure = nil
+}
+
+// createBlueGreenHook declares the hook running blue/green deployments of services with x-aws-blue_green, which requires the
+// AWS::CodeDeployBlueGreen transform. Traffic shift applies to the whole stack update, so all services must use the same one
+func (b *ComposeECS) createBlueGreenHook(project *types.Project, template *cloudformation.Template) (map[string]blueGreenHook, error) {
+	publishedPorts := map[int]string{}
+	for _, service := range project.Services {
+		for _, port := range service.Ports {
+			publishedPorts[int(port.Published)] = service.Name
+		}
+	}
+
+	var (
+		applications []blueGreenApplication
+		trafficShift *blueGreenConfig
+		first        string
+	)
+	for _, service := range project.Services {
+		config, err := blueGreenDeployment(service)
+		if err != nil {
+			return nil, err
+		}
+		if config == nil {
+			continue
+		}
+		if trafficShift == nil {
+			trafficShift, first = config, service.Name
+		} else if !config.sameTrafficShift(*trafficShift) {
+			return nil, fmt.Errorf("services %s and %s must use the same %s traffic shift, as it applies to the whole deployment",
+				first, service.Name, extensionBlueGreen)
+		}
+		if used, ok := publishedPorts[config.TestPort]; ok {
+			return nil, fmt.Errorf("service %s: test port %d is already used by service %s, set test_port in %s", service.Name, config.TestPort, used, extensionBlueGreen)
+		}
+		publishedPorts[config.TestPort] = service.Name
+
+		port := service.Ports[0]
+		applications = append(applications, blueGreenApplication{
+			Target: blueGreenResource{
+				Type:      awsTypeService,
+				LogicalID: serviceResourceName(service.Name),
+			},
+			ECSAttributes: blueGreenECSAttributes{
+				TaskDefinitions: []string{
+					taskDefinitionResourceName(service.Name),
+					fmt.Sprintf("%sGreenTaskDefinition", normalizeResourceName(service.Name)),
+				},
+				TaskSets: []string{
+					taskSetResourceName(service.Name),
+					fmt.Sprintf("%sGreenTaskSet", normalizeResourceName(service.Name)),
+				},
+				TrafficRouting: blueGreenTrafficRouting{
+					ProdTrafficRoute: blueGreenResource{
+						Type:      awsTypeListener,
+						LogicalID: listenerResourceName(service.Name, port),
+					},
+					TestTrafficRoute: blueGreenResource{
+						Type:      awsTypeListener,
+						LogicalID: testListenerResourceName(service.Name, port),
+					},
+					TargetGroups: []string{
+						targetGroupResourceName(service.Name, port),
+						greenTargetGroupResourceName(service.Name, port),
+					},
+				},
+			},
+		})
+	}
+	if len(applications) == 0 {
+		return nil, nil
+	}
+
+	transform := blueGreenTransform
+	template.Transform = &cloudformation.Transform{String: &transform}
+	return map[string]blueGreenHook{
+		blueGreenHookName: {
+			Type: blueGreenHookType,
+			Properties: blueGreenHookProperties{
+				TrafficRoutingConfig: trafficShift.trafficRouting(),
+				Applications:         applications,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,137 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/awslabs/goformation/v4/cloudformation/ecs"
+	"github.com/awslabs/goformation/v4/cloudformation/elasticloadbalancingv2"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestBlueGreenConvert(t *testing.T) {
+	bytes, err := os.ReadFile("testdata/input/blue-green-service.yaml")
+	assert.NilError(t, err)
+	template := convertYaml(t, string(bytes), nil, useDefaultVPC)
+	resultAsJSON, err := marshall(template, "yaml")
+	assert.NilError(t, err)
+	result := fmt.Sprintf("%s\n", string(resultAsJSON))
+	expected := "blue-green-conversion.golden"
+	golden.Assert(t, result, expected)
+}
+
+func TestBlueGreenAllAtOnce(t *testing.T) {
+	template := convertYaml(t, `
+services:
+  foo:
+    image: hello_world
+    ports:
+      - 80:80
+    deploy:
+      update_config:
+        x-aws-blue_green: {}
+  bar:
+    image: hello_world
+`, nil, useDefaultVPC)
+	assert.Equal(t, *template.Transform.String, blueGreenTransform)
+	hook := template.Hooks[blueGreenHookName]
+	assert.DeepEqual(t, hook.Properties.TrafficRoutingConfig, blueGreenTrafficRoutingConfig{Type: "AllAtOnce"})
+	assert.Equal(t, len(hook.Properties.Applications), 1)
+
+	testListener := template.Resources["FooTCP80TestListener"].(*elasticloadbalancingv2.Listener)
+	assert.Equal(t, testListener.Port, blueGreenTestPort)
+
+	service := template.Resources["FooService"].(*ecsService)
+	assert.Equal(t, service.DeploymentController.Type, "EXTERNAL")
+	assert.Check(t, service.DeploymentFailure == nil)
+	assert.Equal(t, service.TaskDefinition, "")
+	taskSet := template.Resources["FooTaskSet"].(*ecs.TaskSet)
+	assert.Equal(t, taskSet.Scale.Value, float64(100))
+
+	service = template.Resources["BarService"].(*ecsService)
+	assert.Equal(t, service.DeploymentController.Type, "ECS")
+}
+
+func TestBlueGreenRequiresSinglePort(t *testing.T) {
+	convertYaml(t, `
+services:
+  foo:
+    image: hello_world
+    ports:
+      - 80:80
+      - 443:443
+    deploy:
+      update_config:
+        x-aws-blue_green: {}
+`, errors.New("service foo: x-aws-blue_green requires a single published port, as CodeDeploy shifts traffic of a single listener"), useDefaultVPC)
+}
+
+func TestBlueGreenTestPortConflict(t *testing.T) {
+	convertYaml(t, `
+services:
+  foo:
+    image: hello_world
+    ports:
+      - 80:80
+    deploy:
+      update_config:
+        x-aws-blue_green:
+          traffic_shift: linear
+  bar:
+    image: hello_world
+    ports:
+      - 8080:8080
+`, errors.New("service foo: test port 8080 is already used by service bar, set test_port in x-aws-blue_green"), useDefaultVPC)
+}
+
+func TestBlueGreenTrafficShiftConflict(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+    ports:
+      - 80:80
+    deploy:
+      update_config:
+        x-aws-blue_green:
+          traffic_shift: canary
+  bar:
+    image: hello_world
+    ports:
+      - 443:443
+    deploy:
+      update_config:
+        x-aws-blue_green:
+          traffic_shift: linear
+          test_port: 8443
+`)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	useDefaultVPC(m.EXPECT())
+
+	backend := &ComposeECS{aws: m}
+	_, err := backend.convert(context.TODO(), project)
+	assert.ErrorContains(t, err, "must use the same x-aws-blue_green traffic shift")
+}
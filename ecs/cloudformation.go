@@ -118,7 +118,7 @@ func (b *ComposeECS) resolveServiceImagesDigests(ctx context.Context, project *t
 	})
 }
 
-func (b *ComposeECS) convert(ctx context.Context, project *types.Project) (*cloudformation.Template, error) {
+func (b *ComposeECS) convert(ctx context.Context, project *types.Project) (*stackTemplate, error) {
 	err := b.checkCompatibility(project)
 	if err != nil {
 		return nil, err
@@ -168,7 +168,15 @@ func (b *ComposeECS) convert(ctx context.Context, project *types.Project) (*clou
 		return nil, err
 	}
 
-	return template, nil
+	hooks, err := b.createBlueGreenHook(project, template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stackTemplate{
+		Template: template,
+		Hooks:    hooks,
+	}, nil
 }
 
 func (b *ComposeECS) createService(project *types.Project, service types.ServiceConfig, template *cloudformation.Template, resources awsResources) error {
@@ -183,13 +191,17 @@ func (b *ComposeECS) createService(project *types.Project, service types.Service
 		return err
 	}
 	definition.ExecutionRoleArn = cloudformation.Ref(taskExecutionRole)
-	if taskRole != "" {
-		definition.TaskRoleArn = cloudformation.Ref(taskRole)
-	}
+	definition.TaskRoleArn = cloudformation.Ref(taskRole)
+	definition.Tags = serviceTags(project, service)
 
-	taskDefinition := fmt.Sprintf("%sTaskDefinition", normalizeResourceName(service.Name))
+	taskDefinition := taskDefinitionResourceName(service.Name)
 	template.Resources[taskDefinition] = definition
 
+	blueGreen, err := blueGreenDeployment(service)
+	if err != nil {
+		return err
+	}
+
 	var healthCheck *cloudmap.Service_HealthCheckConfig
 	serviceRegistry := b.createServiceRegistry(service, template, healthCheck)
 
@@ -210,6 +222,9 @@ func (b *ComposeECS) createService(project *types.Project, service types.Service
 		targetGroupName := b.createTargetGroup(project, service, port, template, protocol, resources.vpc)
 		listenerName := b.createListener(service, port, template, targetGroupName, resources.loadBalancer, protocol)
 		dependsOn = append(dependsOn, listenerName)
+		if blueGreen != nil {
+			dependsOn = append(dependsOn, b.createBlueGreenTargets(service, port, blueGreen.TestPort, template, resources))
+		}
 		serviceLB = append(serviceLB, ecs.Service_LoadBalancer{
 			ContainerName:  service.Name,
 			ContainerPort:  int(port.Target),
@@ -234,6 +249,10 @@ func (b *ComposeECS) createService(project *types.Project, service types.Service
 	if err != nil {
 		return err
 	}
+	deploymentFailure, err := computeDeploymentFailure(project, service)
+	if err != nil {
+		return err
+	}
 
 	assignPublicIP := ecsapi.AssignPublicIpEnabled
 	launchType := ecsapi.LaunchTypeFargate
@@ -244,34 +263,44 @@ func (b *ComposeECS) createService(project *types.Project, service types.Service
 		platformVersion = "" // The platform version must be null when specifying an EC2 launch type
 	}
 
-	template.Resources[serviceResourceName(service.Name)] = &ecs.Service{
-		AWSCloudFormationDependsOn: dependsOn,
-		Cluster:                    resources.cluster.ARN(),
-		DesiredCount:               desiredCount,
-		DeploymentController: &ecs.Service_DeploymentController{
-			Type: ecsapi.DeploymentControllerTypeEcs,
-		},
-		DeploymentConfiguration: &ecs.Service_DeploymentConfiguration{
-			MaximumPercent:        maxPercent,
-			MinimumHealthyPercent: minPercent,
-		},
-		LaunchType: launchType,
-		// TODO we miss support for https://github.com/aws/containers-roadmap/issues/631 to select a capacity provider
-		LoadBalancers: serviceLB,
-		NetworkConfiguration: &ecs.Service_NetworkConfiguration{
-			AwsvpcConfiguration: &ecs.Service_AwsVpcConfiguration{
-				AssignPublicIp: assignPublicIP,
-				SecurityGroups: resources.serviceSecurityGroups(service),
-				Subnets:        resources.subnetsIDs(),
+	serviceResource := &ecsService{
+		Service: ecs.Service{
+			AWSCloudFormationDependsOn: dependsOn,
+			Cluster:                    resources.cluster.ARN(),
+			DesiredCount:               desiredCount,
+			DeploymentController: &ecs.Service_DeploymentController{
+				Type: ecsapi.DeploymentControllerTypeEcs,
+			},
+			DeploymentConfiguration: &ecs.Service_DeploymentConfiguration{
+				MaximumPercent:        maxPercent,
+				MinimumHealthyPercent: minPercent,
+			},
+			LaunchType: launchType,
+			// TODO we miss support for https://github.com/aws/containers-roadmap/issues/631 to select a capacity provider
+			LoadBalancers: serviceLB,
+			NetworkConfiguration: &ecs.Service_NetworkConfiguration{
+				AwsvpcConfiguration: &ecs.Service_AwsVpcConfiguration{
+					AssignPublicIp: assignPublicIP,
+					SecurityGroups: resources.serviceSecurityGroups(service),
+					Subnets:        resources.subnetsIDs(),
+				},
 			},
+			PlatformVersion:    platformVersion,
+			PropagateTags:      ecsapi.PropagateTagsService,
+			SchedulingStrategy: ecsapi.SchedulingStrategyReplica,
+			ServiceRegistries:  []ecs.Service_ServiceRegistry{serviceRegistry},
+			Tags:               serviceTags(project, service),
+			TaskDefinition:     cloudformation.Ref(normalizeResourceName(taskDefinition)),
 		},
-		PlatformVersion:    platformVersion,
-		PropagateTags:      ecsapi.PropagateTagsService,
-		SchedulingStrategy: ecsapi.SchedulingStrategyReplica,
-		ServiceRegistries:  []ecs.Service_ServiceRegistry{serviceRegistry},
-		Tags:               serviceTags(project, service),
-		TaskDefinition:     cloudformation.Ref(normalizeResourceName(taskDefinition)),
+		ecsServiceProperties: ecsServiceProperties{
+			EnableExecuteCommand: true,
+			DeploymentFailure:    deploymentFailure,
+		},
+	}
+	if blueGreen != nil {
+		b.createTaskSet(service, template, serviceResource)
 	}
+	template.Resources[serviceResourceName(service.Name)] = serviceResource
 	return nil
 }
 
@@ -318,9 +347,8 @@ func (b *ComposeECS) createLogGroup(project *types.Project, template *cloudforma
 	if v, ok := project.Extensions[extensionRetention]; ok {
 		retention = v.(int)
 	}
-	logGroup := fmt.Sprintf("/docker-compose/%s", project.Name)
 	template.Resources["LogGroup"] = &logs.LogGroup{
-		LogGroupName:    logGroup,
+		LogGroupName:    logGroupName(project.Name),
 		RetentionInDays: retention,
 	}
 }
@@ -365,15 +393,107 @@ func computeRollingUpdateLimits(service types.ServiceConfig) (int, int, error) {
 	return minPercent, maxPercent, nil
 }
 
+// computeDeploymentFailure returns how ECS handles a failed deployment of service. Deployment circuit breaker is enabled unless
+// x-aws-circuit_breaker is false, and deploy.update_config.failure_action selects whether a failed deployment is rolled back.
+func computeDeploymentFailure(project *types.Project, service types.ServiceConfig) (*serviceDeploymentFailure, error) {
+	circuitBreaker := true
+	rollback := true
+	if service.Deploy == nil || service.Deploy.UpdateConfig == nil {
+		return &serviceDeploymentFailure{
+			DeploymentCircuitBreaker: &serviceDeploymentCircuitBreaker{Enable: circuitBreaker, Rollback: rollback},
+		}, nil
+	}
+	updateConfig := service.Deploy.UpdateConfig
+	switch updateConfig.FailureAction {
+	case "", "rollback":
+	case "pause":
+		rollback = false
+	case "continue":
+		circuitBreaker = false
+		rollback = false
+	default:
+		return nil, fmt.Errorf("service %s: unsupported deploy.update_config.failure_action %q", service.Name, updateConfig.FailureAction)
+	}
+	if x, ok := updateConfig.Extensions[extensionCircuitBreaker]; ok {
+		enable, ok := x.(bool)
+		if !ok {
+			return nil, fmt.Errorf("service %s: %s must be a boolean", service.Name, extensionCircuitBreaker)
+		}
+		if enable && updateConfig.FailureAction == "continue" {
+			return nil, fmt.Errorf("service %s: %s can't be enabled with deploy.update_config.failure_action %q", service.Name, extensionCircuitBreaker, updateConfig.FailureAction)
+		}
+		circuitBreaker = enable
+	}
+
+	failure := &serviceDeploymentFailure{
+		DeploymentCircuitBreaker: &serviceDeploymentCircuitBreaker{
+			Enable:   circuitBreaker,
+			Rollback: circuitBreaker && rollback,
+		},
+	}
+	alarms, err := deploymentAlarms(project, service.Name, updateConfig)
+	if err != nil {
+		return nil, err
+	}
+	if len(alarms) > 0 {
+		failure.Alarms = &serviceDeploymentAlarms{
+			AlarmNames: alarms,
+			Enable:     true,
+			Rollback:   rollback,
+		}
+	}
+	return failure, nil
+}
+
+// deploymentAlarms returns the names of the CloudWatch alarms set by x-aws-alarms, which fail a deployment when triggered. Alarms
+// declared as x-aws-cloudformation resources are referenced by their logical ID, others by their name.
+func deploymentAlarms(project *types.Project, service string, updateConfig *types.UpdateConfig) ([]string, error) {
+	x, ok := updateConfig.Extensions[extensionAlarms]
+	if !ok {
+		return nil, nil
+	}
+	list, ok := x.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("service %s: %s must be a list of alarm names", service, extensionAlarms)
+	}
+	declared := declaredAlarms(project)
+	var alarms []string
+	for _, item := range list {
+		name, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("service %s: %s must be a list of alarm names", service, extensionAlarms)
+		}
+		if declared[name] {
+			name = cloudformation.Ref(name)
+		}
+		alarms = append(alarms, name)
+	}
+	return alarms, nil
+}
+
+// declaredAlarms returns the logical IDs of CloudWatch alarms declared by x-aws-cloudformation
+func declaredAlarms(project *types.Project) map[string]bool {
+	declared := map[string]bool{}
+	overlay, ok := project.Extensions[extensionCloudFormation].(map[string]interface{})
+	if !ok {
+		return declared
+	}
+	resources, ok := overlay["Resources"].(map[string]interface{})
+	if !ok {
+		return declared
+	}
+	for name, r := range resources {
+		if resource, ok := r.(map[string]interface{}); ok && resource["Type"] == awsTypeAlarm {
+			declared[name] = true
+		}
+	}
+	return declared
+}
+
 func (b *ComposeECS) createListener(service types.ServiceConfig, port types.ServicePortConfig,
 	template *cloudformation.Template,
 	targetGroupName string, loadBalancer awsResource, protocol string) string {
-	listenerName := fmt.Sprintf(
-		"%s%s%dListener",
-		normalizeResourceName(service.Name),
-		strings.ToUpper(port.Protocol),
-		port.Target,
-	)
+	listenerName := listenerResourceName(service.Name, port)
 	// add listener to dependsOn
 	// https://stackoverflow.com/questions/53971873/the-target-group-does-not-have-an-associated-load-balancer
 	template.Resources[listenerName] = &elasticloadbalancingv2.Listener{
@@ -397,12 +517,7 @@ func (b *ComposeECS) createListener(service types.ServiceConfig, port types.Serv
 }
 
 func (b *ComposeECS) createTargetGroup(project *types.Project, service types.ServiceConfig, port types.ServicePortConfig, template *cloudformation.Template, protocol string, vpc string) string {
-	targetGroupName := fmt.Sprintf(
-		"%s%s%dTargetGroup",
-		normalizeResourceName(service.Name),
-		strings.ToUpper(port.Protocol),
-		port.Published,
-	)
+	targetGroupName := targetGroupResourceName(service.Name, port)
 	template.Resources[targetGroupName] = &elasticloadbalancingv2.TargetGroup{
 		Port:       int(port.Target),
 		Protocol:   protocol,
@@ -457,7 +572,12 @@ func (b *ComposeECS) createTaskExecutionRole(project *types.Project, service typ
 
 func (b *ComposeECS) createTaskRole(project *types.Project, service types.ServiceConfig, template *cloudformation.Template, resources awsResources) (string, error) {
 	taskRole := fmt.Sprintf("%sTaskRole", normalizeResourceName(service.Name))
-	rolePolicies := []iam.Role_Policy{}
+	rolePolicies := []iam.Role_Policy{
+		{
+			PolicyName:     fmt.Sprintf("%sExecuteCommandPolicy", normalizeResourceName(service.Name)),
+			PolicyDocument: execCommandPolicyDocument(),
+		},
+	}
 	if roles, ok := service.Extensions[extensionRole]; ok {
 		rolePolicies = append(rolePolicies, iam.Role_Policy{
 			PolicyName:     fmt.Sprintf("%sPolicy", normalizeResourceName(service.Name)),
@@ -483,9 +603,6 @@ func (b *ComposeECS) createTaskRole(project *types.Project, service types.Servic
 			managedPolicies = append(managedPolicies, s.(string))
 		}
 	}
-	if len(rolePolicies) == 0 && len(managedPolicies) == 0 {
-		return "", nil
-	}
 	template.Resources[taskRole] = &iam.Role{
 		AssumeRolePolicyDocument: ecsTaskAssumeRolePolicyDocument,
 		Policies:                 rolePolicies,
@@ -538,6 +655,30 @@ func serviceResourceName(service string) string {
 	return fmt.Sprintf("%sService", normalizeResourceName(service))
 }
 
+func taskDefinitionResourceName(service string) string {
+	return fmt.Sprintf("%sTaskDefinition", normalizeResourceName(service))
+}
+
+func taskSetResourceName(service string) string {
+	return fmt.Sprintf("%sTaskSet", normalizeResourceName(service))
+}
+
+func listenerResourceName(service string, port types.ServicePortConfig) string {
+	return fmt.Sprintf("%s%s%dListener", normalizeResourceName(service), strings.ToUpper(port.Protocol), port.Target)
+}
+
+func testListenerResourceName(service string, port types.ServicePortConfig) string {
+	return fmt.Sprintf("%s%s%dTestListener", normalizeResourceName(service), strings.ToUpper(port.Protocol), port.Target)
+}
+
+func targetGroupResourceName(service string, port types.ServicePortConfig) string {
+	return fmt.Sprintf("%s%s%dTargetGroup", normalizeResourceName(service), strings.ToUpper(port.Protocol), port.Published)
+}
+
+func greenTargetGroupResourceName(service string, port types.ServicePortConfig) string {
+	return fmt.Sprintf("%s%s%dGreenTargetGroup", normalizeResourceName(service), strings.ToUpper(port.Protocol), port.Published)
+}
+
 func volumeResourceName(service string) string {
 	return fmt.Sprintf("%sFilesystem", normalizeResourceName(service))
 }
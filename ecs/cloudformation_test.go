@@ -126,7 +126,7 @@ services:
       update_config:
         parallelism: 2
 `, nil, useDefaultVPC)
-	service := template.Resources["FooService"].(*ecs.Service)
+	service := template.Resources["FooService"].(*ecsService)
 	assert.Check(t, service.DeploymentConfiguration.MaximumPercent == 150)
 	assert.Check(t, service.DeploymentConfiguration.MinimumHealthyPercent == 50)
 }
@@ -141,11 +141,87 @@ services:
         x-aws-min_percent: 25
         x-aws-max_percent: 125
 `, nil, useDefaultVPC)
-	service := template.Resources["FooService"].(*ecs.Service)
+	service := template.Resources["FooService"].(*ecsService)
 	assert.Check(t, service.DeploymentConfiguration.MaximumPercent == 125)
 	assert.Check(t, service.DeploymentConfiguration.MinimumHealthyPercent == 25)
 }
 
+func TestDeploymentCircuitBreakerByDefault(t *testing.T) {
+	template := convertYaml(t, `
+services:
+  foo:
+    image: hello_world
+`, nil, useDefaultVPC)
+	service := template.Resources["FooService"].(*ecsService)
+	assert.DeepEqual(t, service.DeploymentFailure, &serviceDeploymentFailure{
+		DeploymentCircuitBreaker: &serviceDeploymentCircuitBreaker{Enable: true, Rollback: true},
+	})
+}
+
+func TestDeploymentFailureAction(t *testing.T) {
+	template := convertYaml(t, `
+services:
+  foo:
+    image: hello_world
+    deploy:
+      update_config:
+        failure_action: pause
+  bar:
+    image: hello_world
+    deploy:
+      update_config:
+        failure_action: continue
+  zot:
+    image: hello_world
+    deploy:
+      update_config:
+        x-aws-circuit_breaker: false
+`, nil, useDefaultVPC)
+	service := template.Resources["FooService"].(*ecsService)
+	assert.DeepEqual(t, service.DeploymentFailure.DeploymentCircuitBreaker, &serviceDeploymentCircuitBreaker{Enable: true, Rollback: false})
+	service = template.Resources["BarService"].(*ecsService)
+	assert.DeepEqual(t, service.DeploymentFailure.DeploymentCircuitBreaker, &serviceDeploymentCircuitBreaker{Enable: false, Rollback: false})
+	service = template.Resources["ZotService"].(*ecsService)
+	assert.DeepEqual(t, service.DeploymentFailure.DeploymentCircuitBreaker, &serviceDeploymentCircuitBreaker{Enable: false, Rollback: false})
+}
+
+func TestDeploymentFailureActionConflict(t *testing.T) {
+	convertYaml(t, `
+services:
+  foo:
+    image: hello_world
+    deploy:
+      update_config:
+        failure_action: continue
+        x-aws-circuit_breaker: true
+`, errors.New(`service foo: x-aws-circuit_breaker can't be enabled with deploy.update_config.failure_action "continue"`), useDefaultVPC)
+}
+
+func TestDeploymentAlarms(t *testing.T) {
+	template := convertYaml(t, `
+services:
+  foo:
+    image: hello_world
+    deploy:
+      update_config:
+        x-aws-alarms:
+          - FooErrorsAlarm
+          - existing-latency-alarm
+x-aws-cloudformation:
+  Resources:
+    FooErrorsAlarm:
+      Type: AWS::CloudWatch::Alarm
+      Properties:
+        MetricName: HTTPCode_Target_5XX_Count
+`, nil, useDefaultVPC)
+	service := template.Resources["FooService"].(*ecsService)
+	assert.DeepEqual(t, service.DeploymentFailure.Alarms, &serviceDeploymentAlarms{
+		AlarmNames: []string{cloudformation.Ref("FooErrorsAlarm"), "existing-latency-alarm"},
+		Enable:     true,
+		Rollback:   true,
+	})
+}
+
 func TestRolePolicy(t *testing.T) {
 	template := convertYaml(t, `
 services:
@@ -166,6 +242,24 @@ services:
 	assert.DeepEqual(t, []string{"secret"}, policy.Statement[0].Resource)
 }
 
+func TestExecuteCommand(t *testing.T) {
+	template := convertYaml(t, `
+services:
+  foo:
+    image: hello_world
+`, nil, useDefaultVPC)
+	service := template.Resources["FooService"].(*ecsService)
+	assert.Check(t, service.EnableExecuteCommand)
+
+	def := template.Resources["FooTaskDefinition"].(*ecs.TaskDefinition)
+	assert.Equal(t, def.TaskRoleArn, cloudformation.Ref("FooTaskRole"))
+	role := template.Resources["FooTaskRole"].(*iam.Role)
+	assert.Check(t, len(role.Policies) == 1)
+	policy := role.Policies[0].PolicyDocument.(PolicyDocument)
+	expected := []string{"ssmmessages:CreateControlChannel", "ssmmessages:CreateDataChannel", "ssmmessages:OpenControlChannel", "ssmmessages:OpenDataChannel"}
+	assert.DeepEqual(t, expected, policy.Statement[0].Action)
+}
+
 func TestMapNetworksToSecurityGroups(t *testing.T) {
 	template := convertYaml(t, `
 services:
@@ -243,7 +337,7 @@ services:
 `, nil, useDefaultVPC)
 	s := template.Resources["TestService"]
 	assert.Check(t, s != nil)
-	service := *s.(*ecs.Service)
+	service := *s.(*ecsService)
 	assert.Check(t, service.DesiredCount == 10)
 }
 
@@ -365,7 +459,7 @@ networks:
 	})
 	assert.Check(t, template.Resources["DefaultNetwork"] == nil)
 	assert.Check(t, template.Resources["DefaultNetworkIngress"] == nil)
-	s := template.Resources["TestService"].(*ecs.Service)
+	s := template.Resources["TestService"].(*ecsService)
 	assert.Check(t, s != nil)                                                                    //nolint:staticcheck
 	assert.Check(t, s.NetworkConfiguration.AwsvpcConfiguration.SecurityGroups[0] == "sg-123abc") //nolint:staticcheck
 }
@@ -579,7 +673,7 @@ services:
 	})
 }
 
-func convertYaml(t *testing.T, yaml string, assertErr error, fn ...func(m *MockAPIMockRecorder)) *cloudformation.Template {
+func convertYaml(t *testing.T, yaml string, assertErr error, fn ...func(m *MockAPIMockRecorder)) *stackTemplate {
 	project := loadConfig(t, yaml)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -72,6 +72,7 @@ var compatibleComposeAttributes = []string{
 	"services.deploy.resources.reservations.generic_resources",
 	"services.deploy.resources.reservations.generic_resources.discrete_resource_spec",
 	"services.deploy.update_config",
+	"services.deploy.update_config.failure_action",
 	"services.deploy.update_config.parallelism",
 	"services.entrypoint",
 	"services.environment",
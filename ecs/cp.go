@@ -0,0 +1,533 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-ecs/utils"
+)
+
+const (
+	// copyMarker prefixes messages our remote scripts print, so they can be distinguished from archive data
+	copyMarker = "compose-ecs-cp:"
+	// base64LineLength is the line length used to send archive data, so it doesn't exceed the remote terminal line buffer
+	base64LineLength = 76
+)
+
+// Copy copies files between local filesystem and a task container. As ECS Exec sessions run commands within a pseudo
+// terminal, the tar archive is base64 encoded, so it is not altered by the terminal line discipline.
+func (b *ComposeECS) Copy(ctx context.Context, project *types.Project, options api.CopyOptions) error {
+	if err := checkUnsupportedCopyOptions(ctx, options); err != nil {
+		return err
+	}
+	srcService, srcPath := splitCopyPath(options.Source)
+	dstService, dstPath := splitCopyPath(options.Destination)
+	switch {
+	case srcService != "" && dstService != "":
+		return errors.New("copying between services is not supported")
+	case srcService == "" && dstService == "":
+		return errors.New("source or destination must be a service path, as SERVICE:PATH")
+	case srcService != "" && options.All:
+		return errors.New("--all can only be used to copy files to a service")
+	}
+
+	cluster, err := b.aws.GetStackClusterID(ctx, project.Name)
+	if err != nil {
+		return err
+	}
+	return progress.Run(ctx, func(ctx context.Context) error {
+		if srcService != "" {
+			task, err := b.selectServiceTask(ctx, cluster, project.Name, srcService, options.Index)
+			if err != nil {
+				return err
+			}
+			return b.copyFromTask(ctx, cluster, task, srcService, srcPath, dstPath, options.FollowLink)
+		}
+
+		tasks := []string{}
+		if options.All {
+			summaries, err := b.aws.DescribeServiceTasks(ctx, cluster, project.Name, dstService)
+			if err != nil {
+				return err
+			}
+			for _, t := range summaries {
+				if t.State == "Running" {
+					tasks = append(tasks, t.ID)
+				}
+			}
+		} else {
+			task, err := b.selectServiceTask(ctx, cluster, project.Name, dstService, options.Index)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		for _, task := range tasks {
+			if err := b.copyToTask(ctx, cluster, task, dstService, srcPath, dstPath, options.FollowLink); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (b *ComposeECS) copyFromTask(ctx context.Context, cluster string, task string, service string, srcPath string, dstPath string, followLink bool) error {
+	dir, name := splitRemotePath(srcPath)
+	flags := "cf"
+	if followLink {
+		flags = "chf"
+	}
+	script := fmt.Sprintf(`if [ ! -e %[1]s ]; then echo %[2]s; exit 1; fi; tar %[3]s - -C %[4]s %[5]s | base64`,
+		shellQuote(srcPath), shellQuote(copyMarker+"no such file or directory"), flags, shellQuote(dir), shellQuote(name))
+
+	id := fmt.Sprintf("%s:%s", taskID(task), srcPath)
+	w := progress.ContextWriter(ctx)
+	w.Event(progress.NewEvent(id, progress.Working, "Copying"))
+
+	r, stdout := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		_, err := b.execScript(ctx, cluster, task, service, script, nil, stdout)
+		stdout.CloseWithError(err) //nolint:errcheck
+		done <- err
+	}()
+	filter := newBase64Filter(r)
+	archive := &progressReader{reader: base64.NewDecoder(base64.StdEncoding, filter), id: id, w: w}
+	err := extractArchive(archive, dstPath, name)
+	// make sure remote command completes, even if we failed to extract the archive
+	r.CloseWithError(err) //nolint:errcheck
+	if e := <-done; err == nil && e != nil && e != io.ErrClosedPipe {
+		err = e
+	}
+	for _, m := range filter.messages {
+		if strings.HasPrefix(m, copyMarker) {
+			return errors.Wrapf(api.ErrNotFound, "%s:%s: %s", service, srcPath, strings.TrimPrefix(m, copyMarker))
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(filter.messages) > 0 {
+		return fmt.Errorf("failed to copy %s:%s: %s", service, srcPath, strings.Join(filter.messages, "\n"))
+	}
+	w.Event(progress.NewEvent(id, progress.Done, fmt.Sprintf("Copied %s", units.HumanSize(float64(archive.count)))))
+	return nil
+}
+
+func (b *ComposeECS) copyToTask(ctx context.Context, cluster string, task string, service string, srcPath string, dstPath string, followLink bool) error {
+	stat := os.Lstat
+	if followLink {
+		stat = os.Stat
+	}
+	if _, err := stat(srcPath); err != nil {
+		return err
+	}
+
+	// destination can be an existing directory to copy source into, or the path to create in an existing directory
+	dir, name := splitRemotePath(dstPath)
+	script := fmt.Sprintf(`if [ -d %[1]s ]; then echo %[2]sdir; elif [ -d %[3]s ]; then echo %[2]sparent; else echo %[2]smissing; fi`,
+		shellQuote(dstPath), copyMarker, shellQuote(dir))
+	var probe bytes.Buffer
+	if _, err := b.execScript(ctx, cluster, task, service, script, nil, &probe); err != nil {
+		return err
+	}
+	switch {
+	case strings.Contains(probe.String(), copyMarker+"dir"):
+		dir, name = dstPath, filepath.Base(srcPath)
+	case strings.Contains(probe.String(), copyMarker+"parent"):
+	default:
+		return errors.Wrapf(api.ErrNotFound, "%s:%s: no such directory", service, dir)
+	}
+
+	id := fmt.Sprintf("%s:%s", taskID(task), dstPath)
+	w := progress.ContextWriter(ctx)
+	w.Event(progress.NewEvent(id, progress.Working, "Copying"))
+
+	r, stdin := io.Pipe()
+	archive := &progressWriter{writer: stdin, id: id, w: w}
+	go func() {
+		lines := &lineWriter{writer: archive, length: base64LineLength}
+		encoder := base64.NewEncoder(base64.StdEncoding, lines)
+		err := createArchive(encoder, srcPath, name, followLink)
+		if err == nil {
+			err = encoder.Close()
+		}
+		if err == nil {
+			// session ends base64 standard input once archive is sent
+			_, err = io.WriteString(stdin, "\n")
+		}
+		stdin.CloseWithError(err) //nolint:errcheck
+	}()
+
+	script = fmt.Sprintf(`stty -echo 2>/dev/null; base64 -d | tar xf - -C %[1]s; echo %[2]s$?`, shellQuote(dir), copyMarker)
+	var output bytes.Buffer
+	_, err := b.execScript(ctx, cluster, task, service, script, r, &output)
+	r.CloseWithError(err) //nolint:errcheck
+	if err != nil {
+		return err
+	}
+	if status := copyStatus(output.String()); status != 0 {
+		return fmt.Errorf("failed to copy %s to %s:%s: %s", srcPath, service, dstPath, copyErrors(output.String()))
+	}
+	w.Event(progress.NewEvent(id, progress.Done, fmt.Sprintf("Copied %s", units.HumanSize(float64(archive.count)))))
+	return nil
+}
+
+// execScript runs a shell script in task container over an ECS Exec session
+func (b *ComposeECS) execScript(ctx context.Context, cluster string, task string, container string, script string, stdin io.Reader, stdout io.Writer) (int, error) {
+	session, err := b.aws.ExecuteCommand(ctx, cluster, task, container, "sh -c "+shellQuote(script))
+	if err != nil {
+		return 0, err
+	}
+	client, err := openSession(ctx, aws.StringValue(session.StreamUrl), aws.StringValue(session.TokenValue))
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close() //nolint:errcheck
+	return client.Run(ctx, stdin, stdout, stdout, nil)
+}
+
+// splitCopyPath splits a SERVICE:PATH argument. Service is empty for a local path.
+func splitCopyPath(arg string) (string, string) {
+	if filepath.IsAbs(arg) || strings.HasPrefix(arg, ".") {
+		return "", arg
+	}
+	service, p, ok := strings.Cut(arg, ":")
+	if !ok || strings.Contains(service, "/") {
+		return "", arg
+	}
+	return service, p
+}
+
+// splitRemotePath returns the parent directory and name of a container path
+func splitRemotePath(p string) (string, string) {
+	p = path.Clean(p)
+	if p == "/" {
+		return "/", "."
+	}
+	dir, name := path.Split(p)
+	if dir == "" {
+		dir = "."
+	}
+	return dir, name
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// copyStatus parses the exit status our remote script prints
+func copyStatus(output string) int {
+	i := strings.LastIndex(output, copyMarker)
+	if i < 0 {
+		return -1
+	}
+	status, err := strconv.Atoi(strings.TrimSpace(output[i+len(copyMarker):]))
+	if err != nil {
+		return -1
+	}
+	return status
+}
+
+// copyErrors returns remote output, without echoed input nor status
+func copyErrors(output string) string {
+	var messages []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" || isBase64(line) || strings.HasPrefix(line, copyMarker) {
+			continue
+		}
+		messages = append(messages, line)
+	}
+	return strings.Join(messages, "\n")
+}
+
+// base64Filter reads base64 data from remote terminal output, collecting other lines as messages
+type base64Filter struct {
+	scanner  *bufio.Scanner
+	buf      []byte
+	messages []string
+}
+
+func newBase64Filter(r io.Reader) *base64Filter {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1024*1024)
+	return &base64Filter{scanner: scanner}
+}
+
+func (f *base64Filter) Read(p []byte) (int, error) {
+	for len(f.buf) == 0 {
+		if !f.scanner.Scan() {
+			if err := f.scanner.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		line := strings.TrimRight(f.scanner.Text(), "\r")
+		if isBase64(line) {
+			f.buf = append(f.buf[:0], line...)
+		} else {
+			f.messages = append(f.messages, line)
+		}
+	}
+	n := copy(p, f.buf)
+	f.buf = f.buf[n:]
+	return n, nil
+}
+
+func isBase64(line string) bool {
+	for _, c := range line {
+		if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '+' || c == '/' || c == '=') {
+			return false
+		}
+	}
+	return true
+}
+
+// lineWriter splits data in lines of given length
+type lineWriter struct {
+	writer io.Writer
+	length int
+	column int
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := l.length - l.column
+		if n > len(p) {
+			n = len(p)
+		}
+		if _, err := l.writer.Write(p[:n]); err != nil {
+			return written, err
+		}
+		written += n
+		l.column += n
+		p = p[n:]
+		if l.column == l.length {
+			if _, err := l.writer.Write([]byte("\n")); err != nil {
+				return written, err
+			}
+			l.column = 0
+		}
+	}
+	return written, nil
+}
+
+type progressReader struct {
+	reader io.Reader
+	id     string
+	w      progress.Writer
+	count  int64
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	p.count += int64(n)
+	p.w.Event(progress.NewEvent(p.id, progress.Working, units.HumanSize(float64(p.count))))
+	return n, err
+}
+
+type progressWriter struct {
+	writer io.Writer
+	id     string
+	w      progress.Writer
+	count  int64
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.writer.Write(b)
+	p.count += int64(n)
+	p.w.Event(progress.NewEvent(p.id, progress.Working, units.HumanSize(float64(p.count))))
+	return n, err
+}
+
+// extractArchive extracts archive with content of name into dst. If dst is an existing directory, name is created
+// within, otherwise dst is created to receive content of name. As the archive comes from the task, entries and symlinks
+// pointing outside of destination are rejected, and entries are never written through a symlink.
+func extractArchive(r io.Reader, dst string, name string) error {
+	root := dst
+	if fi, err := os.Stat(dst); err == nil && fi.IsDir() && name != "." {
+		root = filepath.Join(dst, name)
+	} else if err != nil {
+		parent := filepath.Dir(dst)
+		if _, err := os.Stat(parent); err != nil {
+			return errors.Wrapf(api.ErrNotFound, "destination directory %s doesn't exist", parent)
+		}
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		rel, ok := archiveEntryPath(hdr.Name, name)
+		target := filepath.Join(root, filepath.FromSlash(rel))
+		if !ok || !isWithin(root, target) {
+			return fmt.Errorf("invalid archive entry %q", hdr.Name)
+		}
+		if err := checkNoSymlink(root, target); err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, hdr.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr) //nolint:gosec
+			if e := f.Close(); err == nil {
+				err = e
+			}
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			link := filepath.FromSlash(hdr.Linkname)
+			if filepath.IsAbs(link) || !isWithin(root, filepath.Join(filepath.Dir(target), link)) {
+				return fmt.Errorf("invalid archive entry %q: link to %s is outside of destination", hdr.Name, hdr.Linkname)
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// archiveEntryPath returns the path of an archive entry relative to the copied file or directory name, which the archive is created
+// from. It returns false if entry isn't name or within it.
+func archiveEntryPath(entry string, name string) (string, bool) {
+	entry, name = path.Clean(entry), path.Clean(name)
+	switch {
+	case name == ".":
+		return entry, true
+	case entry == name:
+		return "", true
+	case strings.HasPrefix(entry, name+"/"):
+		return strings.TrimPrefix(entry, name+"/"), true
+	}
+	return "", false
+}
+
+// isWithin tells if p is root or a path within root
+func isWithin(root string, p string) bool {
+	return p == root || strings.HasPrefix(p, root+string(filepath.Separator))
+}
+
+// checkNoSymlink fails if any existing path from root (excluded) to target is a symlink, which writing target would follow
+func checkNoSymlink(root string, target string) error {
+	rel, err := filepath.Rel(root, target)
+	if err != nil || rel == "." {
+		return err
+	}
+	p := root
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		p = filepath.Join(p, name)
+		fi, err := os.Lstat(p)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("refusing to write %s through symlink %s", target, p)
+		}
+	}
+	return nil
+}
+
+// createArchive writes a tar archive with src content as name
+func createArchive(w io.Writer, src string, name string, followLink bool) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(src, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if followLink && fi.Mode()&os.ModeSymlink != 0 {
+			if fi, err = os.Stat(file); err != nil {
+				return err
+			}
+		}
+		var link string
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(name, filepath.ToSlash(rel))
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func checkUnsupportedCopyOptions(ctx context.Context, o api.CopyOptions) error {
+	return utils.CheckUnsupported(ctx, nil, o.CopyUIDGID, false, "cp", "archive")
+}
@@ -0,0 +1,175 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"gotest.tools/v3/assert"
+)
+
+func TestSplitCopyPath(t *testing.T) {
+	for arg, expected := range map[string][2]string{
+		"web:/tmp/heap.hprof": {"web", "/tmp/heap.hprof"},
+		"./web:/tmp":          {"", "./web:/tmp"},
+		"/tmp/a:b":            {"", "/tmp/a:b"},
+		"dir/web:b":           {"", "dir/web:b"},
+		"local.txt":           {"", "local.txt"},
+	} {
+		service, p := splitCopyPath(arg)
+		assert.Equal(t, service, expected[0], arg)
+		assert.Equal(t, p, expected[1], arg)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, shellQuote("/tmp/it's here"), `'/tmp/it'"'"'s here'`)
+}
+
+func TestCopyArchiveThroughTerminal(t *testing.T) {
+	src := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(src, "conf", "sub"), 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(src, "conf", "sub", "app.yaml"), []byte("debug: true\n"), 0o644))
+	content := bytes.Repeat([]byte{0, 1, 2, '\n', '\r', 255}, 1000)
+	assert.NilError(t, os.WriteFile(filepath.Join(src, "conf", "data.bin"), content, 0o600))
+
+	// encode archive as sent to the remote terminal
+	var sent bytes.Buffer
+	encoder := base64.NewEncoder(base64.StdEncoding, &lineWriter{writer: &sent, length: base64LineLength})
+	assert.NilError(t, createArchive(encoder, filepath.Join(src, "conf"), "conf", false))
+	assert.NilError(t, encoder.Close())
+	for _, line := range strings.Split(strings.TrimSpace(sent.String()), "\n") {
+		assert.Check(t, len(line) <= base64LineLength)
+	}
+
+	// terminal output has CRLF line endings, and may include error messages
+	received := "tar: removing leading '/' from member names\r\n" + strings.ReplaceAll(sent.String(), "\n", "\r\n")
+	filter := newBase64Filter(strings.NewReader(received))
+	dst := t.TempDir()
+	assert.NilError(t, extractArchive(base64.NewDecoder(base64.StdEncoding, filter), dst, "conf"))
+	assert.DeepEqual(t, filter.messages, []string{"tar: removing leading '/' from member names"})
+
+	b, err := os.ReadFile(filepath.Join(dst, "conf", "sub", "app.yaml"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(b), "debug: true\n")
+	b, err = os.ReadFile(filepath.Join(dst, "conf", "data.bin"))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, b, content)
+}
+
+func TestExtractArchiveRoot(t *testing.T) {
+	// archive of `cp SERVICE:/ ./out`, created by tar from /
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for _, hdr := range []*tar.Header{
+		{Name: "./", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "./.bashrc", Typeflag: tar.TypeReg, Mode: 0o644, Size: 4},
+		{Name: "./etc/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "./etc/hosts", Typeflag: tar.TypeReg, Mode: 0o644, Size: 4},
+	} {
+		assert.NilError(t, tw.WriteHeader(hdr))
+		if hdr.Typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte("test"))
+			assert.NilError(t, err)
+		}
+	}
+	assert.NilError(t, tw.Close())
+
+	dst := filepath.Join(t.TempDir(), "out")
+	assert.NilError(t, extractArchive(&archive, dst, "."))
+	for _, name := range []string{".bashrc", filepath.Join("etc", "hosts")} {
+		b, err := os.ReadFile(filepath.Join(dst, name))
+		assert.NilError(t, err)
+		assert.Equal(t, string(b), "test")
+	}
+}
+
+func TestArchiveEntryPath(t *testing.T) {
+	for _, tc := range []struct {
+		entry, name, rel string
+		ok               bool
+	}{
+		{entry: "conf", name: "conf", rel: "", ok: true},
+		{entry: "conf/", name: "conf", rel: "", ok: true},
+		{entry: "conf/sub/app.yaml", name: "conf", rel: "sub/app.yaml", ok: true},
+		{entry: "./.bashrc", name: ".", rel: ".bashrc", ok: true},
+		{entry: "config/app.yaml", name: "conf", ok: false},
+	} {
+		rel, ok := archiveEntryPath(tc.entry, tc.name)
+		assert.Equal(t, rel, tc.rel, tc.entry)
+		assert.Equal(t, ok, tc.ok, tc.entry)
+	}
+}
+
+func TestExtractArchiveMissingDestination(t *testing.T) {
+	err := extractArchive(io.MultiReader(), filepath.Join(t.TempDir(), "missing", "heap.hprof"), "heap.hprof")
+	assert.Check(t, api.IsNotFoundError(err))
+}
+
+func TestExtractArchiveRejectsSymlinkEscape(t *testing.T) {
+	for name, entries := range map[string][]*tar.Header{
+		"absolute link": {
+			{Name: "conf/a", Typeflag: tar.TypeSymlink, Linkname: "/home/user"},
+		},
+		"relative link": {
+			{Name: "conf/sub", Typeflag: tar.TypeDir, Mode: 0o755},
+			{Name: "conf/sub/a", Typeflag: tar.TypeSymlink, Linkname: "../../.."},
+		},
+	} {
+		var archive bytes.Buffer
+		tw := tar.NewWriter(&archive)
+		for _, hdr := range entries {
+			assert.NilError(t, tw.WriteHeader(hdr), name)
+		}
+		assert.NilError(t, tw.Close(), name)
+		err := extractArchive(&archive, t.TempDir(), "conf")
+		assert.ErrorContains(t, err, "outside of destination", name)
+	}
+}
+
+func TestExtractArchiveRefusesWritingThroughSymlink(t *testing.T) {
+	outside := t.TempDir()
+	dst := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dst, "conf"), 0o755))
+	assert.NilError(t, os.Symlink(outside, filepath.Join(dst, "conf", "a")))
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	assert.NilError(t, tw.WriteHeader(&tar.Header{Name: "conf/a/.bashrc", Typeflag: tar.TypeReg, Mode: 0o644, Size: 4}))
+	_, err := tw.Write([]byte("evil"))
+	assert.NilError(t, err)
+	assert.NilError(t, tw.Close())
+
+	err = extractArchive(&archive, dst, "conf")
+	assert.ErrorContains(t, err, "through symlink")
+	_, err = os.Stat(filepath.Join(outside, ".bashrc"))
+	assert.Check(t, os.IsNotExist(err))
+}
+
+func TestCopyStatus(t *testing.T) {
+	output := "QUJD\r\ntar: can't open 'app.yaml': Permission denied\r\n" + copyMarker + "1\r\n"
+	assert.Equal(t, copyStatus(output), 1)
+	assert.Equal(t, copyErrors(output), "tar: can't open 'app.yaml': Permission denied")
+}
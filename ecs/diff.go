@@ -0,0 +1,318 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// templateDiffContext is the number of unchanged lines displayed around changed ones
+const templateDiffContext = 3
+
+// Diff prints the changes to the deployed stack template the project model makes, without creating a change set
+func (b *ComposeECS) Diff(ctx context.Context, project *types.Project) error {
+	local, err := b.Convert(ctx, project, api.ConvertOptions{
+		Format: "yaml",
+	})
+	if err != nil {
+		return err
+	}
+	exists, err := b.aws.StackExists(ctx, project.Name)
+	if err != nil {
+		return err
+	}
+	var deployed []byte
+	if exists {
+		deployed, err = b.aws.GetTemplate(ctx, project.Name, "")
+		if err != nil {
+			return err
+		}
+	}
+	return diffTemplates(os.Stdout, deployed, local)
+}
+
+// diffTemplates writes the changes from deployed template to local one. Parameters, resources, outputs... are compared one by one,
+// so changes are reported by resource rather than by line
+func diffTemplates(w io.Writer, deployed []byte, local []byte) error {
+	before, err := templateEntries(deployed)
+	if err != nil {
+		return errors.Wrap(err, "deployed template")
+	}
+	after, err := templateEntries(local)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		from, deployed := before[name]
+		to, local := after[name]
+		switch {
+		case !deployed:
+			writeDiffLines(w, name, '+', to)
+		case !local:
+			writeDiffLines(w, name, '-', from)
+		case from != to:
+			fmt.Fprintf(w, "~ %s\n", name)
+			writeLineDiff(w, diffLines(splitLines(from), splitLines(to)))
+		}
+	}
+	return nil
+}
+
+// templateEntries returns the normalized YAML of each template entry, by path: "Resources.Foo" for the entries of a section,
+// "Description" for a top-level value
+func templateEntries(template []byte) (map[string]string, error) {
+	normalized, err := normalizeTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	entries := map[string]string{}
+	for key, value := range normalized {
+		section, ok := value.(map[string]interface{})
+		if !ok {
+			entries[key], err = marshalEntry(value)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		for name, entry := range section {
+			entries[key+"."+name], err = marshalEntry(entry)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return entries, nil
+}
+
+// marshalEntry renders a normalized value as YAML, with sorted keys
+func marshalEntry(value interface{}) (string, error) {
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// normalizeTemplate decodes a YAML or JSON template, with intrinsic functions in their full form
+func normalizeTemplate(template []byte) (map[string]interface{}, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(template, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	value, err := normalizeNode(document.Content[0])
+	if err != nil {
+		return nil, err
+	}
+	normalized, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("template is not a mapping")
+	}
+	return normalized, nil
+}
+
+func normalizeNode(node *yaml.Node) (interface{}, error) {
+	if function, ok := intrinsicFunction(node.Tag); ok {
+		// short form `!GetAtt Foo.Arn`, decoded as the node without its tag
+		untagged := *node
+		untagged.Tag = ""
+		untagged.Style &^= yaml.TaggedStyle
+		value, err := normalizeNode(&untagged)
+		if err != nil {
+			return nil, err
+		}
+		return normalizeIntrinsicFunction(function, value), nil
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		mapping := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := normalizeNode(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			mapping[node.Content[i].Value] = value
+		}
+		if value, ok := mapping["Fn::GetAtt"]; ok && len(mapping) == 1 {
+			return normalizeIntrinsicFunction("Fn::GetAtt", value), nil
+		}
+		return mapping, nil
+	case yaml.SequenceNode:
+		sequence := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			value, err := normalizeNode(item)
+			if err != nil {
+				return nil, err
+			}
+			sequence[i] = value
+		}
+		return sequence, nil
+	case yaml.AliasNode:
+		return normalizeNode(node.Alias)
+	default:
+		var value interface{}
+		err := node.Decode(&value)
+		return value, err
+	}
+}
+
+// intrinsicFunction returns the full name of the intrinsic function a YAML tag is the short form of
+func intrinsicFunction(tag string) (string, bool) {
+	if !strings.HasPrefix(tag, "!") || strings.HasPrefix(tag, "!!") {
+		return "", false
+	}
+	name := strings.TrimPrefix(tag, "!")
+	switch name {
+	case "Ref", "Condition":
+		return name, true
+	default:
+		return "Fn::" + name, true
+	}
+}
+
+// normalizeIntrinsicFunction returns the full form of a function call. Fn::GetAtt accepts a "Resource.Attribute" string, we always
+// use the list form
+func normalizeIntrinsicFunction(function string, value interface{}) map[string]interface{} {
+	if s, ok := value.(string); ok && function == "Fn::GetAtt" {
+		if resource, attribute, ok := strings.Cut(s, "."); ok {
+			value = []interface{}{resource, attribute}
+		}
+	}
+	return map[string]interface{}{function: value}
+}
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes the changes from a to b, based on their longest common subsequence
+func diffLines(a []string, b []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
+
+// writeLineDiff writes changed lines with their context, and elides other unchanged lines
+func writeLineDiff(w io.Writer, lines []diffLine) {
+	visible := make([]bool, len(lines))
+	for i, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for j := i - templateDiffContext; j <= i+templateDiffContext; j++ {
+			if j >= 0 && j < len(lines) {
+				visible[j] = true
+			}
+		}
+	}
+	elided := false
+	for i, l := range lines {
+		if !visible[i] {
+			elided = true
+			continue
+		}
+		if elided {
+			fmt.Fprintln(w, "    ...")
+			elided = false
+		}
+		fmt.Fprintf(w, "%c   %s\n", l.op, l.text)
+	}
+	if elided {
+		fmt.Fprintln(w, "    ...")
+	}
+}
+
+// writeDiffLines writes an added or removed entry
+func writeDiffLines(w io.Writer, name string, op byte, entry string) {
+	fmt.Fprintf(w, "%c %s\n", op, name)
+	for _, line := range splitLines(entry) {
+		fmt.Fprintf(w, "%c   %s\n", op, line)
+	}
+}
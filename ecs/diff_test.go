@@ -0,0 +1,77 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+func TestDiffTemplates(t *testing.T) {
+	deployed, err := os.ReadFile("testdata/diff-deployed.yaml")
+	assert.NilError(t, err)
+	local, err := os.ReadFile("testdata/diff-local.yaml")
+	assert.NilError(t, err)
+
+	var b bytes.Buffer
+	err = diffTemplates(&b, deployed, local)
+	assert.NilError(t, err)
+	golden.Assert(t, b.String(), "diff.golden")
+}
+
+func TestDiffTemplatesNormalizesIntrinsicFunctions(t *testing.T) {
+	var b bytes.Buffer
+	err := diffTemplates(&b, []byte(`
+Resources:
+  Foo:
+    Type: AWS::ECS::Service
+    Properties:
+      Cluster: !GetAtt Cluster.Arn
+      Role: !Ref Role
+      ServiceName: !Sub "${AWS::StackName}-foo"
+`), []byte(`{
+  "Resources": {
+    "Foo": {
+      "Properties": {
+        "Cluster": {"Fn::GetAtt": "Cluster.Arn"},
+        "Role": {"Ref": "Role"},
+        "ServiceName": {"Fn::Sub": "${AWS::StackName}-foo"}
+      },
+      "Type": "AWS::ECS::Service"
+    }
+  }
+}`))
+	assert.NilError(t, err)
+	assert.Equal(t, b.String(), "")
+}
+
+func TestDiffTemplatesNotDeployed(t *testing.T) {
+	var b bytes.Buffer
+	err := diffTemplates(&b, nil, []byte(`
+Resources:
+  Cluster:
+    Type: AWS::ECS::Cluster
+`))
+	assert.NilError(t, err)
+	assert.Equal(t, b.String(), `+ Resources.Cluster
++   Type: AWS::ECS::Cluster
+`)
+}
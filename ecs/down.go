@@ -18,22 +18,113 @@ package ecs
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/compose-spec/compose-go/types"
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/progress"
 
 	"github.com/docker/compose-ecs/utils"
 )
 
+const mountTargetsPollInterval = 2 * time.Second
+
+// DownOptions are ECS specific options for down
+type DownOptions struct {
+	// Yes skips confirmation before volumes are deleted
+	Yes bool
+	// DryRun lists the resources which would be deleted
+	DryRun bool
+}
+
 func (b *ComposeECS) Down(ctx context.Context, projectName string, options api.DownOptions) error {
 	if err := checkUnsupportedDownOptions(ctx, options); err != nil {
 		return err
 	}
+	var filesystems []projectFileSystem
+	if options.Volumes {
+		var err error
+		filesystems, err = b.projectFileSystems(ctx, projectName, options.Project)
+		if err != nil {
+			return err
+		}
+	}
+	// file systems deleted by --volumes are not orphans
+	orphansProject := options.Project
+	if options.Volumes {
+		orphansProject = nil
+	}
+	if b.DownOptions.DryRun {
+		return b.downDryRun(ctx, projectName, options, filesystems, orphansProject)
+	}
+
+	if len(filesystems) > 0 && !b.DownOptions.Yes {
+		var names []string
+		for _, fs := range filesystems {
+			names = append(names, fmt.Sprintf("%s (%s)", fs.Volume, fs.ID))
+		}
+		ok, err := b.prompt.Confirm(fmt.Sprintf("Going to delete EFS file system(s) %s and all their data. Are you sure?", strings.Join(names, ", ")), false)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return api.ErrCanceled
+		}
+	}
 	return progress.Run(ctx, func(ctx context.Context) error {
-		return b.down(ctx, projectName)
+		// volumes and orphans remain once stack is deleted, so they can be deleted by a later `down`
+		exists := true
+		if options.Volumes || options.RemoveOrphans {
+			var err error
+			exists, err = b.aws.StackExists(ctx, projectName)
+			if err != nil {
+				return err
+			}
+		}
+		if exists {
+			if err := b.down(ctx, projectName); err != nil {
+				return err
+			}
+		}
+		if err := b.deleteFileSystems(ctx, filesystems); err != nil {
+			return err
+		}
+		if !options.RemoveOrphans {
+			return nil
+		}
+		orphans, err := b.listOrphans(ctx, projectName, orphansProject, nil)
+		if err != nil {
+			return err
+		}
+		return b.removeOrphans(ctx, orphans)
 	})
 }
 
+func (b *ComposeECS) downDryRun(ctx context.Context, projectName string, options api.DownOptions, filesystems []projectFileSystem, orphansProject *types.Project) error {
+	exists, err := b.aws.StackExists(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		fmt.Fprintf(os.Stdout, "Would remove CloudFormation stack %s\n", projectName)
+	}
+	for _, fs := range filesystems {
+		fmt.Fprintf(os.Stdout, "Would remove EFS file system %s (volume %s)\n", fs.ID, fs.Volume)
+	}
+	if !options.RemoveOrphans {
+		return nil
+	}
+	orphans, err := b.listOrphans(ctx, projectName, orphansProject, nil)
+	if err != nil {
+		return err
+	}
+	printOrphans(os.Stdout, orphans)
+	return nil
+}
+
 func (b *ComposeECS) down(ctx context.Context, projectName string) error {
 	resources, err := b.aws.ListStackResources(ctx, projectName)
 	if err != nil {
@@ -88,15 +179,93 @@ func doDelete(ctx context.Context, delete func(ctx context.Context, arn string)
 	}
 }
 
+// projectFileSystems returns the EFS file systems created for project volumes. Those are tagged by project and volume name, while
+// external volumes are not, but we also exclude volumes project declares as external in case they have been tagged by user
+func (b *ComposeECS) projectFileSystems(ctx context.Context, projectName string, project *types.Project) ([]projectFileSystem, error) {
+	filesystems, err := b.aws.ListProjectFileSystems(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return filesystems, nil
+	}
+	external := map[string]bool{}
+	for name, volume := range project.Volumes {
+		if volume.External.External {
+			external[name] = true
+			external[volume.Name] = true
+		}
+	}
+	var selected []projectFileSystem
+	for _, fs := range filesystems {
+		if external[fs.Volume] || external[fs.ID] {
+			continue
+		}
+		selected = append(selected, fs)
+	}
+	return selected, nil
+}
+
+func (b *ComposeECS) deleteFileSystems(ctx context.Context, filesystems []projectFileSystem) error {
+	w := progress.ContextWriter(ctx)
+	for _, fs := range filesystems {
+		eventName := fmt.Sprintf("Volume %s", fs.Volume)
+		w.Event(progress.RemovingEvent(eventName))
+		if err := b.deleteFileSystem(ctx, fs.ID); err != nil {
+			w.Event(progress.ErrorEvent(eventName))
+			return err
+		}
+		w.Event(progress.RemovedEvent(eventName))
+	}
+	return nil
+}
+
+// deleteFileSystem deletes an EFS file system, after the access points and mount targets which are not deleted with stack
+func (b *ComposeECS) deleteFileSystem(ctx context.Context, id string) error {
+	accessPoints, err := b.aws.ListAccessPoints(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, ap := range accessPoints {
+		if err := b.aws.DeleteAccessPoint(ctx, ap); err != nil {
+			return err
+		}
+	}
+
+	mountTargets, err := b.aws.ListMountTargets(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, mt := range mountTargets {
+		if err := b.aws.DeleteMountTarget(ctx, mt); err != nil {
+			return err
+		}
+	}
+	// file system can't be deleted until mount targets deletion completes
+	for len(mountTargets) > 0 {
+		mountTargets, err = b.aws.ListMountTargets(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(mountTargets) == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(mountTargetsPollInterval):
+		}
+	}
+	return b.aws.DeleteFileSystem(ctx, id)
+}
+
 func checkUnsupportedDownOptions(ctx context.Context, o api.DownOptions) error {
 	var errs error
 	checks := []struct {
 		toCheck, expected interface{}
 		option            string
 	}{
-		{o.Volumes, false, "volumes"},
 		{o.Images, "", "images"},
-		{o.RemoveOrphans, false, "remove-orphans"},
 		{o.Timeout, nil, "timeout"},
 	}
 	for _, c := range checks {
@@ -0,0 +1,89 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+// answer is a prompt.UI answering all questions the same
+type answer bool
+
+func (a answer) Confirm(string, bool) (bool, error) {
+	return bool(a), nil
+}
+
+func TestProjectFileSystemsExcludesExternalVolumes(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+volumes:
+  db:
+  shared:
+    external: true
+    name: fs-shared
+`)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().ListProjectFileSystems(gomock.Any(), t.Name()).Return([]projectFileSystem{
+		{Volume: "db", ID: "fs-db"},
+		{Volume: "other", ID: "fs-shared"},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	filesystems, err := backend.projectFileSystems(context.TODO(), t.Name(), project)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, filesystems, []projectFileSystem{{Volume: "db", ID: "fs-db"}})
+}
+
+func TestDeleteFileSystem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	gomock.InOrder(
+		m.EXPECT().ListAccessPoints(gomock.Any(), "fs-db").Return([]string{"fsap-1"}, nil),
+		m.EXPECT().DeleteAccessPoint(gomock.Any(), "fsap-1").Return(nil),
+		m.EXPECT().ListMountTargets(gomock.Any(), "fs-db").Return([]string{"fsmt-1", "fsmt-2"}, nil),
+		m.EXPECT().DeleteMountTarget(gomock.Any(), "fsmt-1").Return(nil),
+		m.EXPECT().DeleteMountTarget(gomock.Any(), "fsmt-2").Return(nil),
+		m.EXPECT().ListMountTargets(gomock.Any(), "fs-db").Return(nil, nil),
+		m.EXPECT().DeleteFileSystem(gomock.Any(), "fs-db").Return(nil),
+	)
+
+	backend := &ComposeECS{aws: m}
+	err := backend.deleteFileSystem(context.TODO(), "fs-db")
+	assert.NilError(t, err)
+}
+
+func TestDownVolumesNotConfirmed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().ListProjectFileSystems(gomock.Any(), t.Name()).Return([]projectFileSystem{
+		{Volume: "db", ID: "fs-db"},
+	}, nil)
+	backend := &ComposeECS{aws: m, prompt: answer(false)}
+	err := backend.Down(context.TODO(), t.Name(), api.DownOptions{Volumes: true})
+	assert.Assert(t, api.IsErrCanceled(err))
+}
@@ -0,0 +1,194 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+const eventsPollInterval = 5 * time.Second
+
+// EventsOptions are ECS specific options for events
+type EventsOptions struct {
+	// Follow keeps polling for new events
+	Follow bool
+}
+
+type projectEvent struct {
+	id string
+	api.Event
+}
+
+// Events merges CloudFormation stack events, ECS service events and tasks stopped reasons in a single time-ordered stream
+func (b *ComposeECS) Events(ctx context.Context, project string, options api.EventsOptions) error {
+	cluster, err := b.aws.GetStackClusterID(ctx, project)
+	if err != nil {
+		return err
+	}
+	// Get the unique Stack ID so we don't get events from previous deployments with same name
+	stackID, err := b.aws.GetStackID(ctx, project)
+	if err != nil {
+		return err
+	}
+	resources, err := b.aws.ListStackResources(ctx, project)
+	if err != nil {
+		return err
+	}
+	resources = selectServices(resources, options.Services)
+
+	knownEvents := map[string]struct{}{}
+	ticker := time.NewTicker(eventsPollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := b.projectEvents(ctx, cluster, stackID, resources, len(options.Services) > 0, knownEvents)
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Timestamp.Before(events[j].Timestamp)
+		})
+		for _, e := range events {
+			knownEvents[e.id] = struct{}{}
+			if err := options.Consumer(e.Event); err != nil {
+				return err
+			}
+		}
+
+		if !b.EventsOptions.Follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// projectEvents collects events not in knownEvents. When filtered is set, stack events are restricted to ECS services resources
+func (b *ComposeECS) projectEvents(ctx context.Context, cluster string, stackID string, resources stackResources, filtered bool, knownEvents map[string]struct{}) ([]projectEvent, error) {
+	var arns []string
+	services := map[string]bool{}
+	for _, r := range resources {
+		if r.Type == awsTypeService && r.ARN != "" {
+			arns = append(arns, r.ARN)
+			services[r.LogicalID] = true
+		}
+	}
+
+	var events []projectEvent
+	stackEvents, err := b.aws.DescribeStackEvents(ctx, stackID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range stackEvents {
+		id := aws.StringValue(e.EventId)
+		if _, ok := knownEvents[id]; ok {
+			continue
+		}
+		resource := aws.StringValue(e.LogicalResourceId)
+		if filtered && !services[resource] {
+			continue
+		}
+		events = append(events, projectEvent{
+			id: id,
+			Event: api.Event{
+				Timestamp: aws.TimeValue(e.Timestamp),
+				Container: resource,
+				Status:    aws.StringValue(e.ResourceStatus),
+				Attributes: map[string]string{
+					"source": "cloudformation",
+					"type":   aws.StringValue(e.ResourceType),
+					"reason": aws.StringValue(e.ResourceStatusReason),
+				},
+			},
+		})
+	}
+
+	described, err := b.aws.DescribeServices(ctx, cluster, arns)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range described {
+		name := serviceName(service)
+		for _, e := range service.Events {
+			id := aws.StringValue(e.Id)
+			if _, ok := knownEvents[id]; ok {
+				continue
+			}
+			events = append(events, projectEvent{
+				id: id,
+				Event: api.Event{
+					Timestamp: aws.TimeValue(e.CreatedAt),
+					Service:   name,
+					Container: aws.StringValue(service.ServiceName),
+					Status:    aws.StringValue(e.Message),
+					Attributes: map[string]string{
+						"source": "ecs",
+					},
+				},
+			})
+		}
+
+		tasks, err := b.aws.GetServiceTasks(ctx, cluster, aws.StringValue(service.ServiceArn), true)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			arn := aws.StringValue(t.TaskArn)
+			id := arn + "/stopped"
+			if _, ok := knownEvents[id]; ok || t.StoppedAt == nil {
+				continue
+			}
+			reason, err := b.aws.GetTaskStoppedReason(ctx, cluster, arn)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, projectEvent{
+				id: id,
+				Event: api.Event{
+					Timestamp: aws.TimeValue(t.StoppedAt),
+					Service:   name,
+					Container: taskID(arn),
+					Status:    "stopped",
+					Attributes: map[string]string{
+						"source": "ecs",
+						"task":   arn,
+						"reason": reason,
+					},
+				},
+			})
+		}
+	}
+	return events, nil
+}
+
+// serviceName returns the compose service name an ECS service has been created for
+func serviceName(service *ecs.Service) string {
+	for _, t := range service.Tags {
+		if aws.StringValue(t.Key) == api.ServiceLabel {
+			return aws.StringValue(t.Value)
+		}
+	}
+	return aws.StringValue(service.ServiceName)
+}
@@ -0,0 +1,88 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	now := time.Now()
+	m.EXPECT().GetStackClusterID(gomock.Any(), t.Name()).Return("cluster", nil)
+	m.EXPECT().GetStackID(gomock.Any(), t.Name()).Return("stack-id", nil)
+	m.EXPECT().ListStackResources(gomock.Any(), t.Name()).Return(stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:foo"},
+		{LogicalID: "FooTaskDefinition", Type: "AWS::ECS::TaskDefinition", ARN: "arn:foo-task"},
+	}, nil)
+	m.EXPECT().DescribeStackEvents(gomock.Any(), "stack-id").Return([]*cloudformation.StackEvent{
+		{
+			EventId:           aws.String("1"),
+			LogicalResourceId: aws.String("FooService"),
+			ResourceStatus:    aws.String("UPDATE_IN_PROGRESS"),
+			ResourceType:      aws.String(awsTypeService),
+			Timestamp:         aws.Time(now.Add(-3 * time.Minute)),
+		},
+		{
+			EventId:           aws.String("2"),
+			LogicalResourceId: aws.String("FooTaskDefinition"),
+			ResourceStatus:    aws.String("UPDATE_COMPLETE"),
+			Timestamp:         aws.Time(now.Add(-4 * time.Minute)),
+		},
+	}, nil)
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{
+			ServiceArn:  aws.String("arn:foo"),
+			ServiceName: aws.String("foo-service"),
+			Tags:        []*ecs.Tag{{Key: aws.String(api.ServiceLabel), Value: aws.String("foo")}},
+			Events: []*ecs.ServiceEvent{
+				{Id: aws.String("3"), CreatedAt: aws.Time(now.Add(-1 * time.Minute)), Message: aws.String("(service foo-service) has reached a steady state.")},
+			},
+		},
+	}, nil)
+	m.EXPECT().GetServiceTasks(gomock.Any(), "cluster", "arn:foo", true).Return([]*ecs.Task{
+		{TaskArn: aws.String("arn:aws:ecs:eu-west-3:123456789012:task/cluster/111"), StoppedAt: aws.Time(now.Add(-2 * time.Minute))},
+	}, nil)
+	m.EXPECT().GetTaskStoppedReason(gomock.Any(), "cluster", "arn:aws:ecs:eu-west-3:123456789012:task/cluster/111").Return("EssentialContainerExited: Essential container in task exited", nil)
+
+	var events []api.Event
+	backend := &ComposeECS{aws: m}
+	err := backend.Events(context.TODO(), t.Name(), api.EventsOptions{
+		Services: []string{"foo"},
+		Consumer: func(event api.Event) error {
+			events = append(events, event)
+			return nil
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(events), 3)
+	assert.Equal(t, events[0].Status, "UPDATE_IN_PROGRESS")
+	assert.Equal(t, events[1].Container, "111")
+	assert.Equal(t, events[1].Attributes["reason"], "EssentialContainerExited: Essential container in task exited")
+	assert.Equal(t, events[2].Service, "foo")
+}
@@ -0,0 +1,151 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/moby/term"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-ecs/utils"
+)
+
+const terminalSizePollInterval = 500 * time.Millisecond
+
+func (b *ComposeECS) Exec(ctx context.Context, project string, opts api.RunOptions) (int, error) {
+	if err := checkUnsupportedExecOptions(ctx, opts); err != nil {
+		return 0, err
+	}
+
+	cluster, err := b.aws.GetStackClusterID(ctx, project)
+	if err != nil {
+		return 0, err
+	}
+	task, err := b.selectServiceTask(ctx, cluster, project, opts.Service, opts.Index)
+	if err != nil {
+		return 0, err
+	}
+
+	session, err := b.aws.ExecuteCommand(ctx, cluster, task, opts.Service, shellCommand(opts.Command))
+	if err != nil {
+		return 0, err
+	}
+	client, err := openSession(ctx, aws.StringValue(session.StreamUrl), aws.StringValue(session.TokenValue))
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close() //nolint:errcheck
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	resize := make(chan terminalSize)
+	fd, isTerminal := term.GetFdInfo(os.Stdin)
+	if opts.Tty && isTerminal {
+		state, err := term.SetRawTerminal(fd)
+		if err != nil {
+			return 0, err
+		}
+		defer term.RestoreTerminal(fd, state) //nolint:errcheck
+		go monitorTerminalSize(ctx, fd, resize)
+	}
+	return client.Run(ctx, os.Stdin, os.Stdout, os.Stderr, resize)
+}
+
+// shellCommand quotes each argument of command, so ECS Exec preserves argument boundaries
+func shellCommand(command []string) string {
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// selectServiceTask returns the ARN of the running task for service with given (1-based) index
+func (b *ComposeECS) selectServiceTask(ctx context.Context, cluster string, project string, service string, index int) (string, error) {
+	tasks, err := b.aws.DescribeServiceTasks(ctx, cluster, project, service)
+	if err != nil {
+		return "", err
+	}
+	var running []string
+	for _, t := range tasks {
+		// tasks started by `run` share the service task definition family
+		if t.State == "Running" && t.Labels[api.OneoffLabel] != "True" {
+			running = append(running, t.ID)
+		}
+	}
+	if len(running) == 0 {
+		return "", errors.Wrapf(api.ErrNotFound, "service %q has no running task", service)
+	}
+	sort.Strings(running)
+	if index == 0 {
+		index = 1
+	}
+	if index < 1 || index > len(running) {
+		return "", errors.Wrapf(api.ErrNotFound, "service %q has no task with index %d, only %d running", service, index, len(running))
+	}
+	return running[index-1], nil
+}
+
+// monitorTerminalSize polls local terminal size, as the session-manager-plugin does, so we also support platforms without SIGWINCH
+func monitorTerminalSize(ctx context.Context, fd uintptr, resize chan<- terminalSize) {
+	var current terminalSize
+	ticker := time.NewTicker(terminalSizePollInterval)
+	defer ticker.Stop()
+	for {
+		if ws, err := term.GetWinsize(fd); err == nil {
+			size := terminalSize{Cols: uint32(ws.Width), Rows: uint32(ws.Height)}
+			if size != current {
+				current = size
+				select {
+				case resize <- size:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func checkUnsupportedExecOptions(ctx context.Context, o api.RunOptions) error {
+	var errs error
+	checks := []struct {
+		toCheck, expected interface{}
+		option            string
+	}{
+		{o.Detach, false, "detach"},
+		{o.Privileged, false, "privileged"},
+		{o.User, "", "user"},
+		{o.WorkingDir, "", "workdir"},
+		{len(o.Environment), 0, "env"},
+	}
+	for _, c := range checks {
+		errs = utils.CheckUnsupported(ctx, errs, c.toCheck, c.expected, "exec", c.option)
+	}
+	return errs
+}
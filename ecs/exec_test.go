@@ -0,0 +1,48 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestShellCommand(t *testing.T) {
+	assert.Equal(t, shellCommand([]string{"sh", "-c", "echo a; echo b"}), `'sh' '-c' 'echo a; echo b'`)
+}
+
+func TestSelectServiceTaskSkipsOneOffTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().DescribeServiceTasks(gomock.Any(), "cluster", t.Name(), "foo").Return([]api.ContainerSummary{
+		{ID: "arn:task/a", State: "Running", Labels: map[string]string{api.OneoffLabel: "True"}},
+		{ID: "arn:task/b", State: "Running", Labels: map[string]string{api.OneoffLabel: "False"}},
+		{ID: "arn:task/c", State: "Running"},
+	}, nil).Times(2)
+
+	backend := &ComposeECS{aws: m}
+	task, err := backend.selectServiceTask(context.TODO(), "cluster", t.Name(), "foo", 1)
+	assert.NilError(t, err)
+	assert.Equal(t, task, "arn:task/b")
+	_, err = backend.selectServiceTask(context.TODO(), "cluster", t.Name(), "foo", 3)
+	assert.ErrorContains(t, err, "only 2 running")
+}
@@ -0,0 +1,146 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"encoding/json"
+
+	"github.com/awslabs/goformation/v4/cloudformation"
+	"github.com/awslabs/goformation/v4/cloudformation/ecs"
+)
+
+// stackTemplate is a CloudFormation template with the Hooks section goformation doesn't support (yet)
+type stackTemplate struct {
+	*cloudformation.Template
+	Hooks map[string]blueGreenHook
+}
+
+// blueGreenHook is an AWS::CodeDeploy::BlueGreen hook, which runs blue/green deployments of ECS services when stack is updated
+type blueGreenHook struct {
+	Type       string                  `json:"Type"`
+	Properties blueGreenHookProperties `json:"Properties"`
+}
+
+type blueGreenHookProperties struct {
+	TrafficRoutingConfig blueGreenTrafficRoutingConfig `json:"TrafficRoutingConfig"`
+	Applications         []blueGreenApplication        `json:"Applications"`
+}
+
+type blueGreenTrafficRoutingConfig struct {
+	Type            string                 `json:"Type"`
+	TimeBasedCanary *blueGreenTrafficShift `json:"TimeBasedCanary,omitempty"`
+	TimeBasedLinear *blueGreenTrafficShift `json:"TimeBasedLinear,omitempty"`
+}
+
+type blueGreenTrafficShift struct {
+	StepPercentage int `json:"StepPercentage"`
+	BakeTimeMins   int `json:"BakeTimeMins"`
+}
+
+type blueGreenApplication struct {
+	Target        blueGreenResource      `json:"Target"`
+	ECSAttributes blueGreenECSAttributes `json:"ECSAttributes"`
+}
+
+type blueGreenResource struct {
+	Type      string `json:"Type"`
+	LogicalID string `json:"LogicalID"`
+}
+
+// blueGreenECSAttributes lists the blue resources declared by template, and the green ones the hook creates on deployment
+type blueGreenECSAttributes struct {
+	TaskDefinitions []string                `json:"TaskDefinitions"`
+	TaskSets        []string                `json:"TaskSets"`
+	TrafficRouting  blueGreenTrafficRouting `json:"TrafficRouting"`
+}
+
+type blueGreenTrafficRouting struct {
+	ProdTrafficRoute blueGreenResource `json:"ProdTrafficRoute"`
+	TestTrafficRoute blueGreenResource `json:"TestTrafficRoute"`
+	TargetGroups     []string          `json:"TargetGroups"`
+}
+
+// ecsService is an AWS::ECS::Service with properties goformation doesn't support (yet)
+type ecsService struct {
+	ecs.Service
+	ecsServiceProperties
+}
+
+type ecsServiceProperties struct {
+	EnableExecuteCommand bool `json:"EnableExecuteCommand,omitempty"`
+	// DeploymentFailure is merged into DeploymentConfiguration
+	DeploymentFailure *serviceDeploymentFailure `json:"DeploymentConfiguration,omitempty"`
+}
+
+// serviceDeploymentFailure configures how ECS detects and handles a failed deployment
+type serviceDeploymentFailure struct {
+	DeploymentCircuitBreaker *serviceDeploymentCircuitBreaker `json:"DeploymentCircuitBreaker,omitempty"`
+	Alarms                   *serviceDeploymentAlarms         `json:"Alarms,omitempty"`
+}
+
+type serviceDeploymentCircuitBreaker struct {
+	Enable   bool `json:"Enable"`
+	Rollback bool `json:"Rollback"`
+}
+
+type serviceDeploymentAlarms struct {
+	AlarmNames []string `json:"AlarmNames"`
+	Enable     bool     `json:"Enable"`
+	Rollback   bool     `json:"Rollback"`
+}
+
+// MarshalJSON adds the extra properties to the AWS CloudFormation resource 'Properties' field
+func (r ecsService) MarshalJSON() ([]byte, error) {
+	b, err := r.Service.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var resource map[string]interface{}
+	if err := json.Unmarshal(b, &resource); err != nil {
+		return nil, err
+	}
+
+	b, err = json.Marshal(r.ecsServiceProperties)
+	if err != nil {
+		return nil, err
+	}
+	var extra map[string]interface{}
+	if err := json.Unmarshal(b, &extra); err != nil {
+		return nil, err
+	}
+
+	properties, ok := resource["Properties"].(map[string]interface{})
+	if !ok {
+		properties = map[string]interface{}{}
+	}
+	mergeProperties(properties, extra)
+	resource["Properties"] = properties
+	return json.Marshal(resource)
+}
+
+// mergeProperties deep merges source into target
+func mergeProperties(target map[string]interface{}, source map[string]interface{}) {
+	for key, value := range source {
+		if s, ok := value.(map[string]interface{}); ok {
+			if t, ok := target[key].(map[string]interface{}); ok {
+				mergeProperties(t, s)
+				continue
+			}
+		}
+		target[key] = value
+	}
+}
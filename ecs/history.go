@@ -0,0 +1,321 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-ecs/internal"
+)
+
+// historyBucketPrefix is the name prefix of the S3 buckets storing deployed templates, one per account and region
+const historyBucketPrefix = "compose-ecs-history-"
+
+// S3 user metadata of revisions, in canonical form as returned by S3
+const (
+	revisionProjectHashMetadata = "Project-Hash"
+	revisionVersionMetadata     = "Version"
+	revisionUserMetadata        = "User"
+)
+
+// callerIdentity is the AWS account and identity used to deploy
+type callerIdentity struct {
+	Account string
+	ARN     string
+}
+
+// DeploymentRevision is a template deployed for a project, with the details of its deployment
+type DeploymentRevision struct {
+	Revision int
+	Deployed time.Time
+	// ProjectHash identifies the compose model the template was generated from, it is not set for change sets applied by `apply`
+	ProjectHash string `json:",omitempty"`
+	Version     string
+	User        string
+	// digest is the MD5 hash of the template
+	digest string
+}
+
+func revisionKey(project string, revision int) string {
+	return fmt.Sprintf("%s/%08d.yaml", project, revision)
+}
+
+// pendingRevisionKey is the key of the template deployed by a detached `up`, until stack deployment is seen complete. It isn't
+// parsed as a revision.
+func pendingRevisionKey(project string) string {
+	return project + "/pending.yaml"
+}
+
+func parseRevisionKey(project string, key string) (int, bool) {
+	name := strings.TrimPrefix(key, project+"/")
+	if name == key || !strings.HasSuffix(name, ".yaml") {
+		return 0, false
+	}
+	revision, err := strconv.Atoi(strings.TrimSuffix(name, ".yaml"))
+	return revision, err == nil
+}
+
+func toDeploymentRevision(revision int, deployed *time.Time, metadata map[string]*string) DeploymentRevision {
+	return DeploymentRevision{
+		Revision:    revision,
+		Deployed:    aws.TimeValue(deployed),
+		ProjectHash: aws.StringValue(metadata[revisionProjectHashMetadata]),
+		Version:     aws.StringValue(metadata[revisionVersionMetadata]),
+		User:        aws.StringValue(metadata[revisionUserMetadata]),
+	}
+}
+
+// deploymentCompleted returns true if stack status is the one of a successful deployment, which hasn't been rolled back
+func deploymentCompleted(status string) bool {
+	switch status {
+	case cloudformation.StackStatusCreateComplete, cloudformation.StackStatusUpdateComplete,
+		cloudformation.StackStatusUpdateCompleteCleanupInProgress:
+		return true
+	}
+	return false
+}
+
+// projectHash identifies the compose model a template is generated from
+func projectHash(project *types.Project) string {
+	model, err := project.MarshalYAML()
+	if err != nil {
+		return ""
+	}
+	digest := sha256.Sum256(model)
+	return hex.EncodeToString(digest[:])
+}
+
+func (b *ComposeECS) historyBucket(ctx context.Context) (string, callerIdentity, error) {
+	identity, err := b.aws.GetCallerIdentity(ctx)
+	if err != nil {
+		return "", callerIdentity{}, err
+	}
+	return historyBucketPrefix + identity.Account + "-" + b.Region, identity, nil
+}
+
+// History returns the revisions deployed for project, latest first
+func (b *ComposeECS) History(ctx context.Context, project string) ([]DeploymentRevision, error) {
+	bucket, identity, err := b.historyBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.storePendingRevision(ctx, bucket, identity, project); err != nil {
+		return nil, err
+	}
+	revisions, err := b.aws.ListDeploymentRevisions(ctx, bucket, identity.Account, project)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+	for i, r := range revisions {
+		revisions[i], err = b.aws.DescribeDeploymentRevision(ctx, bucket, identity.Account, project, r.Revision)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return revisions, nil
+}
+
+// recordDeployment stores template deployed for project as a new revision, unless it is the latest one. It must be called once stack
+// deployment has completed, so a failed or rolled back deployment isn't selected by rollback. History isn't required to deploy, so we
+// only warn on failure.
+func (b *ComposeECS) recordDeployment(ctx context.Context, project string, hash string, template []byte) {
+	if err := b.storeRevision(ctx, project, hash, template); err != nil {
+		logrus.Warnf("failed to record deployment of project %s in history: %s", project, err)
+	}
+}
+
+func (b *ComposeECS) storeRevision(ctx context.Context, project string, hash string, template []byte) error {
+	status, err := b.aws.GetStackStatus(ctx, project)
+	if err != nil {
+		return err
+	}
+	if !deploymentCompleted(status) {
+		logrus.Debugf("stack %s is %s, deployment isn't recorded", project, status)
+		return nil
+	}
+	bucket, identity, err := b.historyBucket(ctx)
+	if err != nil {
+		return err
+	}
+	return b.putRevision(ctx, bucket, identity.Account, project, DeploymentRevision{
+		ProjectHash: hash,
+		Version:     internal.Version,
+		User:        identity.ARN,
+	}, template)
+}
+
+// putRevision stores template as the revision following the latest one, unless it is the latest one's template
+func (b *ComposeECS) putRevision(ctx context.Context, bucket string, owner string, project string, revision DeploymentRevision, template []byte) error {
+	revisions, err := b.aws.ListDeploymentRevisions(ctx, bucket, owner, project)
+	if err != nil {
+		return err
+	}
+	var latest DeploymentRevision
+	for _, r := range revisions {
+		if r.Revision > latest.Revision {
+			latest = r
+		}
+	}
+	digest := md5.Sum(template) //nolint:gosec
+	if latest.digest == hex.EncodeToString(digest[:]) {
+		return nil
+	}
+	revision.Revision = latest.Revision + 1
+	return b.aws.PutDeploymentRevision(ctx, bucket, owner, b.Region, project, revision, template)
+}
+
+// deferDeployment stores template deployed by a detached `up` as pending, as stack deployment can still fail or be rolled back. It is
+// recorded by the next command which sees the deployment completed.
+func (b *ComposeECS) deferDeployment(ctx context.Context, project string, hash string, template []byte) {
+	err := func() error {
+		bucket, identity, err := b.historyBucket(ctx)
+		if err != nil {
+			return err
+		}
+		return b.aws.PutPendingDeploymentRevision(ctx, bucket, identity.Account, b.Region, project, DeploymentRevision{
+			ProjectHash: hash,
+			Version:     internal.Version,
+			User:        identity.ARN,
+		}, template)
+	}()
+	if err != nil {
+		logrus.Warnf("failed to record deployment of project %s in history: %s", project, err)
+	}
+}
+
+// recordPendingDeployment records the pending deployment of project, if any, before it is deployed again
+func (b *ComposeECS) recordPendingDeployment(ctx context.Context, project string) {
+	err := func() error {
+		bucket, identity, err := b.historyBucket(ctx)
+		if err != nil {
+			return err
+		}
+		return b.storePendingRevision(ctx, bucket, identity, project)
+	}()
+	if err != nil {
+		logrus.Warnf("failed to record deployment of project %s in history: %s", project, err)
+	}
+}
+
+// storePendingRevision stores the pending deployment of project as a new revision once stack deployment has completed. Pending
+// deployment is discarded if it failed, or if stack has been deployed with another template since.
+func (b *ComposeECS) storePendingRevision(ctx context.Context, bucket string, identity callerIdentity, project string) error {
+	pending, template, err := b.aws.GetPendingDeploymentRevision(ctx, bucket, identity.Account, project)
+	if errors.Is(err, api.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	status, err := b.aws.GetStackStatus(ctx, project)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(status, "_IN_PROGRESS") && !deploymentCompleted(status) {
+		logrus.Debugf("stack %s is %s, pending deployment isn't recorded yet", project, status)
+		return nil
+	}
+	if deploymentCompleted(status) {
+		deployed, err := b.aws.GetTemplate(ctx, project, "")
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(deployed, template) {
+			if err := b.putRevision(ctx, bucket, identity.Account, project, pending, template); err != nil {
+				return err
+			}
+		}
+	}
+	return b.aws.DeletePendingDeploymentRevision(ctx, bucket, identity.Account, project)
+}
+
+// Rollback redeploys the template of a previous revision of project, and waits for deployment to complete. Revision 0 selects the
+// one before latest.
+func (b *ComposeECS) Rollback(ctx context.Context, project string, revision int) error {
+	if err := checkOnInterruptPolicy(b.UpOptions.OnInterrupt); err != nil {
+		return err
+	}
+	err := progress.Run(ctx, func(ctx context.Context) error {
+		return b.rollback(ctx, project, revision)
+	})
+	var interrupted *deploymentInterrupted
+	if !errors.As(err, &interrupted) {
+		return err
+	}
+	return b.onInterrupt(ctx, interrupted)
+}
+
+func (b *ComposeECS) rollback(ctx context.Context, project string, revision int) error {
+	bucket, identity, err := b.historyBucket(ctx)
+	if err != nil {
+		return err
+	}
+	if err := b.storePendingRevision(ctx, bucket, identity, project); err != nil {
+		return err
+	}
+	if revision == 0 {
+		revisions, err := b.aws.ListDeploymentRevisions(ctx, bucket, identity.Account, project)
+		if err != nil {
+			return err
+		}
+		if len(revisions) < 2 {
+			return errors.Wrapf(api.ErrNotFound, "project %s has no previous revision", project)
+		}
+		sort.Slice(revisions, func(i, j int) bool {
+			return revisions[i].Revision > revisions[j].Revision
+		})
+		revision = revisions[1].Revision
+	}
+	target, template, err := b.aws.GetDeploymentRevision(ctx, bucket, identity.Account, project, revision)
+	if err != nil {
+		return err
+	}
+
+	operation, previousEvents, err := b.deployStack(ctx, project, template)
+	if err != nil {
+		return err
+	}
+	err = b.WaitStackCompletion(ctx, project, operation, previousEvents...)
+	if ctx.Err() == context.Canceled {
+		return &deploymentInterrupted{stack: project, operation: operation}
+	}
+	if err != nil {
+		return err
+	}
+	b.recordDeployment(ctx, project, target.ProjectHash, template)
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-ecs/internal"
+)
+
+const (
+	testAccount       = "123456789012"
+	testHistoryBucket = "compose-ecs-history-123456789012-eu-west-3"
+	testUser          = "arn:aws:iam::123456789012:user/deployer"
+	testTemplate      = "Resources: {}\n"
+	// testTemplateDigest is the MD5 hash of testTemplate
+	testTemplateDigest = "340b908c5f5df1bf0ad01dabebcab210"
+)
+
+func expectCallerIdentity(m *MockAPIMockRecorder) {
+	m.GetCallerIdentity(gomock.Any()).Return(callerIdentity{Account: testAccount, ARN: testUser}, nil)
+}
+
+// expectNoPendingDeployment expects the pending deployment of project to be looked up when its history is read
+func expectNoPendingDeployment(m *MockAPIMockRecorder, project string) {
+	m.GetPendingDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, project).Return(DeploymentRevision{}, nil, api.ErrNotFound)
+}
+
+// expectRecordDeployment expects template to be recorded as revision, after revision 1 which has another template
+func expectRecordDeployment(m *MockAPIMockRecorder, project string, hash string, revision int) {
+	m.GetStackStatus(gomock.Any(), project).Return(cloudformation.StackStatusUpdateComplete, nil)
+	expectCallerIdentity(m)
+	var revisions []DeploymentRevision
+	for i := 1; i < revision; i++ {
+		revisions = append(revisions, DeploymentRevision{Revision: i, digest: "0123456789abcdef"})
+	}
+	m.ListDeploymentRevisions(gomock.Any(), testHistoryBucket, testAccount, project).Return(revisions, nil)
+	m.PutDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, "eu-west-3", project, DeploymentRevision{
+		Revision:    revision,
+		ProjectHash: hash,
+		Version:     internal.Version,
+		User:        testUser,
+	}, gomock.Any()).Return(nil)
+}
+
+func TestParseRevisionKey(t *testing.T) {
+	key := revisionKey("foo", 12)
+	assert.Equal(t, key, "foo/00000012.yaml")
+	revision, ok := parseRevisionKey("foo", key)
+	assert.Assert(t, ok)
+	assert.Equal(t, revision, 12)
+
+	_, ok = parseRevisionKey("foo", "foobar/00000012.yaml")
+	assert.Assert(t, !ok)
+}
+
+func TestRecordDeploymentSkipsLatestRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().GetStackStatus(gomock.Any(), t.Name()).Return(cloudformation.StackStatusUpdateComplete, nil)
+	expectCallerIdentity(m.EXPECT())
+	m.EXPECT().ListDeploymentRevisions(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return([]DeploymentRevision{
+		{Revision: 1, digest: "0123456789abcdef"},
+		{Revision: 2, digest: testTemplateDigest},
+	}, nil)
+
+	backend := &ComposeECS{aws: m, Region: "eu-west-3"}
+	err := backend.storeRevision(context.TODO(), t.Name(), "hash", []byte(testTemplate))
+	assert.NilError(t, err)
+}
+
+func TestRecordDeploymentSkipsRolledBackStack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().GetStackStatus(gomock.Any(), t.Name()).Return(cloudformation.StackStatusUpdateRollbackComplete, nil)
+
+	backend := &ComposeECS{aws: m, Region: "eu-west-3"}
+	err := backend.storeRevision(context.TODO(), t.Name(), "hash", []byte(testTemplate))
+	assert.NilError(t, err)
+}
+
+func TestRecordPendingDeployment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	pending := DeploymentRevision{ProjectHash: "hash", Version: "v1.0.0", User: "arn:aws:iam::123456789012:user/other"}
+	m.EXPECT().GetPendingDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return(pending, []byte(testTemplate), nil)
+	m.EXPECT().GetStackStatus(gomock.Any(), t.Name()).Return(cloudformation.StackStatusUpdateComplete, nil)
+	m.EXPECT().GetTemplate(gomock.Any(), t.Name(), "").Return([]byte(testTemplate), nil)
+	m.EXPECT().ListDeploymentRevisions(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return([]DeploymentRevision{
+		{Revision: 1, digest: "0123456789abcdef"},
+	}, nil)
+	pending.Revision = 2
+	m.EXPECT().PutDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, "eu-west-3", t.Name(), pending, []byte(testTemplate)).Return(nil)
+	m.EXPECT().DeletePendingDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return(nil)
+
+	backend := &ComposeECS{aws: m, Region: "eu-west-3"}
+	err := backend.storePendingRevision(context.TODO(), testHistoryBucket, callerIdentity{Account: testAccount, ARN: testUser}, t.Name())
+	assert.NilError(t, err)
+}
+
+func TestRecordPendingDeploymentInProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().GetPendingDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return(DeploymentRevision{}, []byte(testTemplate), nil)
+	m.EXPECT().GetStackStatus(gomock.Any(), t.Name()).Return(cloudformation.StackStatusUpdateInProgress, nil)
+
+	backend := &ComposeECS{aws: m, Region: "eu-west-3"}
+	err := backend.storePendingRevision(context.TODO(), testHistoryBucket, callerIdentity{Account: testAccount, ARN: testUser}, t.Name())
+	assert.NilError(t, err)
+}
+
+func TestRecordPendingDeploymentDiscardsRolledBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().GetPendingDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return(DeploymentRevision{}, []byte(testTemplate), nil)
+	m.EXPECT().GetStackStatus(gomock.Any(), t.Name()).Return(cloudformation.StackStatusUpdateRollbackComplete, nil)
+	m.EXPECT().DeletePendingDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return(nil)
+
+	backend := &ComposeECS{aws: m, Region: "eu-west-3"}
+	err := backend.storePendingRevision(context.TODO(), testHistoryBucket, callerIdentity{Account: testAccount, ARN: testUser}, t.Name())
+	assert.NilError(t, err)
+}
+
+func TestRecordPendingDeploymentDiscardsRedeployed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().GetPendingDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return(DeploymentRevision{}, []byte(testTemplate), nil)
+	m.EXPECT().GetStackStatus(gomock.Any(), t.Name()).Return(cloudformation.StackStatusUpdateComplete, nil)
+	m.EXPECT().GetTemplate(gomock.Any(), t.Name(), "").Return([]byte("Resources: {Foo: {}}\n"), nil)
+	m.EXPECT().DeletePendingDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return(nil)
+
+	backend := &ComposeECS{aws: m, Region: "eu-west-3"}
+	err := backend.storePendingRevision(context.TODO(), testHistoryBucket, callerIdentity{Account: testAccount, ARN: testUser}, t.Name())
+	assert.NilError(t, err)
+}
+
+func TestRollbackPreviousRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectCallerIdentity(m.EXPECT())
+	expectNoPendingDeployment(m.EXPECT(), t.Name())
+	m.EXPECT().ListDeploymentRevisions(gomock.Any(), testHistoryBucket, testAccount, t.Name()).Return([]DeploymentRevision{
+		{Revision: 1}, {Revision: 2}, {Revision: 3},
+	}, nil)
+	m.EXPECT().GetDeploymentRevision(gomock.Any(), testHistoryBucket, testAccount, t.Name(), 2).Return(DeploymentRevision{
+		Revision:    2,
+		ProjectHash: "hash",
+	}, []byte(testTemplate), nil)
+
+	m.EXPECT().StackExists(gomock.Any(), t.Name()).Return(true, nil)
+	m.EXPECT().GetStackStatus(gomock.Any(), t.Name()).Return(cloudformation.StackStatusUpdateComplete, nil)
+	m.EXPECT().DescribeStackEvents(gomock.Any(), t.Name()).Return(nil, nil)
+	m.EXPECT().CreateChangeSet(gomock.Any(), t.Name(), cloudformation.ChangeSetTypeUpdate, "eu-west-3", []byte(testTemplate)).Return(testChangeSet, nil)
+	m.EXPECT().UpdateStack(gomock.Any(), testChangeSet).Return(nil)
+	expectRecordDeployment(m.EXPECT(), t.Name(), "hash", 4)
+	m.EXPECT().GetStackID(gomock.Any(), t.Name()).Return("stack-id", nil)
+	m.EXPECT().WaitStackComplete(gomock.Any(), "stack-id", stackUpdate).Return(nil)
+	m.EXPECT().DescribeStackEvents(gomock.Any(), "stack-id").Return(nil, nil)
+
+	backend := &ComposeECS{aws: m, Region: "eu-west-3"}
+	err := backend.rollback(context.TODO(), t.Name(), 0)
+	assert.NilError(t, err)
+}
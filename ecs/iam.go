@@ -34,6 +34,11 @@ const (
 	actionGetMetrics      = "cloudwatch:GetMetricStatistics"
 	actionDescribeService = "ecs:DescribeServices"
 	actionUpdateService   = "ecs:UpdateService"
+
+	actionCreateControlChannel = "ssmmessages:CreateControlChannel"
+	actionCreateDataChannel    = "ssmmessages:CreateDataChannel"
+	actionOpenControlChannel   = "ssmmessages:OpenControlChannel"
+	actionOpenDataChannel      = "ssmmessages:OpenDataChannel"
 )
 
 var (
@@ -82,6 +87,25 @@ func volumeMountPolicyDocument(volume string, filesystem string) PolicyDocument
 	}
 }
 
+// execCommandPolicyDocument grants the SSM agent running in the task the permissions to open ECS Exec sessions
+func execCommandPolicyDocument() PolicyDocument {
+	return PolicyDocument{
+		Version: "2012-10-17", // https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_elements_version.html
+		Statement: []PolicyStatement{
+			{
+				Effect: "Allow",
+				Action: []string{
+					actionCreateControlChannel,
+					actionCreateDataChannel,
+					actionOpenControlChannel,
+					actionOpenDataChannel,
+				},
+				Resource: []string{"*"},
+			},
+		},
+	}
+}
+
 // PolicyDocument describes an IAM policy document
 // could alternatively depend on https://github.com/kubernetes-sigs/cluster-api-provider-aws/blob/master/cmd/clusterawsadm/api/iam/v1alpha1/types.go
 type PolicyDocument struct {
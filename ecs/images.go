@@ -0,0 +1,179 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	ecsapi "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/cnabio/cnab-to-oci/remotes"
+	ctrremotes "github.com/containerd/containerd/remotes"
+	"github.com/distribution/distribution/v3/reference"
+	cliconfig "github.com/docker/cli/cli/config"
+	"github.com/docker/compose/v2/pkg/api"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// manifest is either an image manifest or an index, as served by registry
+type manifest struct {
+	MediaType string               `json:"mediaType"`
+	Config    ocispec.Descriptor   `json:"config"`
+	Layers    []ocispec.Descriptor `json:"layers"`
+	Manifests []ocispec.Descriptor `json:"manifests"`
+}
+
+// Images lists images of containers from the task definitions currently used by services. Size is the compressed
+// size of image layers, as declared by registry manifest.
+func (b *ComposeECS) Images(ctx context.Context, projectName string, options api.ImagesOptions) ([]api.ImageSummary, error) {
+	cluster, err := b.aws.GetStackClusterID(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := b.aws.ListStackResources(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
+	var arns []string
+	for _, r := range selectServices(resources, options.Services) {
+		if r.Type == awsTypeService && r.ARN != "" {
+			arns = append(arns, r.ARN)
+		}
+	}
+	services, err := b.aws.DescribeServices(ctx, cluster, arns)
+	if err != nil {
+		return nil, err
+	}
+
+	configFile, err := cliconfig.Load(cliconfig.Dir())
+	if err != nil {
+		return nil, err
+	}
+	resolver := remotes.CreateResolver(configFile)
+
+	var summary []api.ImageSummary
+	for _, service := range services {
+		definition, _, err := b.aws.DescribeTaskDefinition(ctx, serviceTaskDefinition(service))
+		if err != nil {
+			return nil, err
+		}
+		for _, container := range definition.ContainerDefinitions {
+			image, err := imageSummary(serviceName(service), container)
+			if err != nil {
+				return nil, err
+			}
+			image.Size, err = imageSize(ctx, resolver, aws.StringValue(container.Image), taskPlatform(definition))
+			if err != nil {
+				logrus.Warnf("failed to get image %s size: %s", aws.StringValue(container.Image), err)
+			}
+			summary = append(summary, image)
+		}
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].ContainerName < summary[j].ContainerName
+	})
+	return summary, nil
+}
+
+// imageSummary describes a container image. Sidecar containers are listed under the service name, so they can be
+// distinguished from the service container.
+func imageSummary(service string, container *ecsapi.ContainerDefinition) (api.ImageSummary, error) {
+	named, err := reference.ParseNormalizedNamed(aws.StringValue(container.Image))
+	if err != nil {
+		return api.ImageSummary{}, err
+	}
+	summary := api.ImageSummary{
+		ContainerName: aws.StringValue(container.Name),
+		Repository:    reference.FamiliarName(named),
+	}
+	if tagged, ok := named.(reference.Tagged); ok {
+		summary.Tag = tagged.Tag()
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		summary.ID = digested.Digest().String()
+	}
+	if strings.HasSuffix(summary.ContainerName, "_InitContainer") {
+		summary.ContainerName = fmt.Sprintf("%s/%s (sidecar)", service, summary.ContainerName)
+	}
+	return summary, nil
+}
+
+// taskPlatform is the platform images are pulled for, based on task definition runtime platform
+func taskPlatform(definition *ecsapi.TaskDefinition) ocispec.Platform {
+	platform := ocispec.Platform{OS: "linux", Architecture: "amd64"}
+	if p := definition.RuntimePlatform; p != nil && aws.StringValue(p.CpuArchitecture) == ecsapi.CPUArchitectureArm64 {
+		platform.Architecture = "arm64"
+	}
+	return platform
+}
+
+func imageSize(ctx context.Context, resolver ctrremotes.Resolver, image string, platform ocispec.Platform) (int64, error) {
+	name, desc, err := resolver.Resolve(ctx, image)
+	if err != nil {
+		return 0, err
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	fetch := func(desc ocispec.Descriptor) (manifest, error) {
+		var m manifest
+		r, err := fetcher.Fetch(ctx, desc)
+		if err != nil {
+			return m, err
+		}
+		defer r.Close() //nolint:errcheck
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return m, err
+		}
+		return m, json.Unmarshal(b, &m)
+	}
+
+	m, err := fetch(desc)
+	if err != nil {
+		return 0, err
+	}
+	if len(m.Manifests) > 0 {
+		// multi-platform image
+		var found bool
+		for _, d := range m.Manifests {
+			if d.Platform != nil && d.Platform.OS == platform.OS && d.Platform.Architecture == platform.Architecture {
+				m, err = fetch(d)
+				if err != nil {
+					return 0, err
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("no manifest for platform %s/%s", platform.OS, platform.Architecture)
+		}
+	}
+	size := m.Config.Size
+	for _, l := range m.Layers {
+		size += l.Size
+	}
+	return size, nil
+}
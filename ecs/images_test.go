@@ -0,0 +1,60 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+	"gotest.tools/v3/assert"
+)
+
+func TestImageSummary(t *testing.T) {
+	const digest = "sha256:1d7c4bc9de1a6d1e3fbd1b8c0b3c5a0c7c0d0c4f4a1c1b6f0c8a0a1e0a2a3b4c"
+	summary, err := imageSummary("web", &ecs.ContainerDefinition{
+		Name:  aws.String("web"),
+		Image: aws.String("nginx:1.25@" + digest),
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, summary, api.ImageSummary{
+		ID:            digest,
+		ContainerName: "web",
+		Repository:    "nginx",
+		Tag:           "1.25",
+	})
+
+	summary, err = imageSummary("web", &ecs.ContainerDefinition{
+		Name:  aws.String("Web_Secrets_InitContainer"),
+		Image: aws.String(secretsInitContainerImage),
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, summary, api.ImageSummary{
+		ContainerName: "web/Web_Secrets_InitContainer (sidecar)",
+		Repository:    "docker/ecs-secrets-sidecar",
+		Tag:           "1.0",
+	})
+}
+
+func TestTaskPlatform(t *testing.T) {
+	platform := taskPlatform(&ecs.TaskDefinition{
+		RuntimePlatform: &ecs.RuntimePlatform{CpuArchitecture: aws.String(ecs.CPUArchitectureArm64)},
+	})
+	assert.Equal(t, platform.Architecture, "arm64")
+	assert.Equal(t, taskPlatform(&ecs.TaskDefinition{}).Architecture, "amd64")
+}
@@ -0,0 +1,164 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/pkg/errors"
+)
+
+// Policies applied when user interrupts a deployment
+const (
+	// OnInterruptDelete deletes the stack
+	OnInterruptDelete = "delete"
+	// OnInterruptRollback cancels an update so the stack is rolled back. As CloudFormation can't cancel a creation, stack is deleted.
+	OnInterruptRollback = "rollback"
+	// OnInterruptDetach lets deployment complete in background
+	OnInterruptDetach = "detach"
+)
+
+// deploymentInterrupted is returned by up when user interrupted while waiting for stack deployment to complete
+type deploymentInterrupted struct {
+	stack     string
+	operation int
+}
+
+func (e *deploymentInterrupted) Error() string {
+	return fmt.Sprintf("deployment of stack %s interrupted", e.stack)
+}
+
+func checkOnInterruptPolicy(policy string) error {
+	switch policy {
+	case "", OnInterruptDelete, OnInterruptRollback, OnInterruptDetach:
+		return nil
+	}
+	return fmt.Errorf("invalid --on-interrupt policy %q, must be one of %s, %s or %s", policy, OnInterruptDelete, OnInterruptRollback, OnInterruptDetach)
+}
+
+// onInterrupt applies interrupt policy to an interrupted deployment. ctx has been cancelled by interruption, so we only keep its values.
+// Another interruption while policy is applied detaches.
+func (b *ComposeECS) onInterrupt(ctx context.Context, interrupted *deploymentInterrupted) error {
+	policy, err := b.interruptPolicy(interrupted)
+	if err != nil {
+		return err
+	}
+	if policy == OnInterruptDetach {
+		return errors.Wrapf(api.ErrCanceled, "stack %s deployment continues in background", interrupted.stack)
+	}
+
+	ctx, cancel := context.WithCancel(withoutCancel(ctx))
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err = progress.Run(ctx, func(ctx context.Context) error {
+		if interrupted.operation == stackUpdate && policy == OnInterruptRollback {
+			return b.rollbackUpdate(ctx, interrupted.stack)
+		}
+		return b.down(ctx, interrupted.stack)
+	})
+	if ctx.Err() != nil {
+		return errors.Wrapf(api.ErrCanceled, "stack %s %s continues in background", interrupted.stack, policy)
+	}
+	if err != nil {
+		return err
+	}
+	if interrupted.operation == stackUpdate && policy == OnInterruptRollback {
+		return errors.Wrapf(api.ErrCanceled, "stack %s update has been rolled back", interrupted.stack)
+	}
+	return errors.Wrapf(api.ErrCanceled, "stack %s has been deleted", interrupted.stack)
+}
+
+// interruptPolicy returns the policy set by user. By default, we roll back updates, and ask before deleting a stack being created
+func (b *ComposeECS) interruptPolicy(interrupted *deploymentInterrupted) (string, error) {
+	if b.UpOptions.OnInterrupt != "" {
+		return b.UpOptions.OnInterrupt, nil
+	}
+	if interrupted.operation == stackUpdate {
+		return OnInterruptRollback, nil
+	}
+	ok, err := b.prompt.Confirm(fmt.Sprintf("Deployment of stack %s has been interrupted. Delete stack?", interrupted.stack), false)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return OnInterruptDelete, nil
+	}
+	return OnInterruptDetach, nil
+}
+
+// rollbackUpdate cancels stack update, and reports events until it has been rolled back
+func (b *ComposeECS) rollbackUpdate(ctx context.Context, name string) error {
+	previousEvents, err := b.previousStackEvents(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := b.aws.CancelUpdateStack(ctx, name); err != nil {
+		return err
+	}
+	// resources which update is cancelled are reported as failed, so we rely on stack status
+	err = b.WaitStackCompletion(ctx, name, stackUpdate, previousEvents...)
+	if ctx.Err() != nil {
+		return err
+	}
+	status, err := b.aws.GetStackStatus(ctx, name)
+	if err != nil {
+		return err
+	}
+	if status != cloudformation.StackStatusUpdateRollbackComplete {
+		return fmt.Errorf("stack %s has not been rolled back, status is %s", name, status)
+	}
+	return nil
+}
+
+// detachedContext has the values of its parent context, but is not cancelled with it
+type detachedContext struct {
+	context.Context
+}
+
+func withoutCancel(ctx context.Context) context.Context {
+	return detachedContext{ctx}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestInterruptPolicy(t *testing.T) {
+	backend := &ComposeECS{prompt: answer(true)}
+	policy, err := backend.interruptPolicy(&deploymentInterrupted{stack: t.Name(), operation: stackUpdate})
+	assert.NilError(t, err)
+	assert.Equal(t, policy, OnInterruptRollback)
+
+	policy, err = backend.interruptPolicy(&deploymentInterrupted{stack: t.Name(), operation: stackCreate})
+	assert.NilError(t, err)
+	assert.Equal(t, policy, OnInterruptDelete)
+
+	backend.prompt = answer(false)
+	policy, err = backend.interruptPolicy(&deploymentInterrupted{stack: t.Name(), operation: stackCreate})
+	assert.NilError(t, err)
+	assert.Equal(t, policy, OnInterruptDetach)
+
+	backend.UpOptions.OnInterrupt = OnInterruptDelete
+	policy, err = backend.interruptPolicy(&deploymentInterrupted{stack: t.Name(), operation: stackUpdate})
+	assert.NilError(t, err)
+	assert.Equal(t, policy, OnInterruptDelete)
+
+	assert.ErrorContains(t, checkOnInterruptPolicy("ignore"), "invalid --on-interrupt policy")
+}
+
+func TestRollbackUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().DescribeStackEvents(gomock.Any(), t.Name()).Return([]*cloudformation.StackEvent{
+		{EventId: aws.String("1")},
+	}, nil)
+	m.EXPECT().CancelUpdateStack(gomock.Any(), t.Name()).Return(nil)
+	m.EXPECT().GetStackID(gomock.Any(), t.Name()).Return("stack-id", nil)
+	m.EXPECT().WaitStackComplete(gomock.Any(), "stack-id", stackUpdate).Return(nil)
+	m.EXPECT().DescribeStackEvents(gomock.Any(), "stack-id").Return([]*cloudformation.StackEvent{
+		{EventId: aws.String("1"), Timestamp: aws.Time(time.Now().Add(-time.Minute))},
+		{
+			EventId:              aws.String("2"),
+			LogicalResourceId:    aws.String("FooService"),
+			ResourceStatus:       aws.String(cloudformation.ResourceStatusUpdateFailed),
+			ResourceStatusReason: aws.String("Resource update cancelled"),
+			Timestamp:            aws.Time(time.Now()),
+		},
+	}, nil)
+	m.EXPECT().GetStackStatus(gomock.Any(), t.Name()).Return(cloudformation.StackStatusUpdateRollbackComplete, nil)
+
+	backend := &ComposeECS{aws: m}
+	err := backend.rollbackUpdate(context.TODO(), t.Name())
+	assert.NilError(t, err)
+}
+
+func TestWithoutCancel(t *testing.T) {
+	type key struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.TODO(), key{}, "value"))
+	cancel()
+	detached := withoutCancel(ctx)
+	assert.NilError(t, detached.Err())
+	assert.Equal(t, detached.Value(key{}), "value")
+}
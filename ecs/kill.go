@@ -0,0 +1,200 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-ecs/utils"
+)
+
+const (
+	killTaskReason       = "Killed by compose-ecs"
+	killTaskPollInterval = time.Second
+)
+
+// KillOptions are ECS specific options for kill
+type KillOptions struct {
+	// Reason is recorded as the tasks stopped reason
+	Reason string
+	// Tasks restricts kill to the tasks with these IDs
+	Tasks []string
+}
+
+type killedTask struct {
+	arn        string
+	serviceArn string
+}
+
+// Kill stops running tasks of services. ECS will start new tasks to replace them.
+func (b *ComposeECS) Kill(ctx context.Context, project *types.Project, options api.KillOptions) error {
+	if err := checkUnsupportedKillOptions(ctx, options); err != nil {
+		return err
+	}
+	var killed []killedTask
+	var cluster string
+	err := progress.Run(ctx, func(ctx context.Context) error {
+		var err error
+		cluster, err = b.aws.GetStackClusterID(ctx, project.Name)
+		if err != nil {
+			return err
+		}
+		killed, err = b.kill(ctx, cluster, project, options.Services, b.KillOptions)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	for _, t := range killed {
+		reason, err := b.aws.GetTaskStoppedReason(ctx, cluster, t.arn)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", t.arn, ecs.DesiredStatusStopped, reason)
+	}
+	return nil
+}
+
+func (b *ComposeECS) kill(ctx context.Context, cluster string, project *types.Project, services []string, options KillOptions) ([]killedTask, error) {
+	selected, err := project.GetServices(services...)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := b.aws.ListStackResources(ctx, project.Name)
+	if err != nil {
+		return nil, err
+	}
+	serviceArns := map[string]string{}
+	for _, r := range resources {
+		if r.Type == awsTypeService {
+			serviceArns[r.LogicalID] = r.ARN
+		}
+	}
+
+	var killed []killedTask
+	for _, service := range selected {
+		serviceArn, ok := serviceArns[serviceResourceName(service.Name)]
+		if !ok {
+			return nil, errors.Wrapf(api.ErrNotFound, "service %q is not deployed by project %q", service.Name, project.Name)
+		}
+		tasks, err := b.aws.DescribeServiceTasks(ctx, cluster, project.Name, service.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tasks {
+			if t.State != "Running" || !matchTask(t.ID, options.Tasks) {
+				continue
+			}
+			killed = append(killed, killedTask{arn: t.ID, serviceArn: serviceArn})
+		}
+	}
+	if len(killed) == 0 {
+		if len(options.Tasks) > 0 {
+			return nil, errors.Wrapf(api.ErrNotFound, "no running task matches %s", strings.Join(options.Tasks, ", "))
+		}
+		return nil, nil
+	}
+
+	reason := options.Reason
+	if reason == "" {
+		reason = killTaskReason
+	}
+	w := progress.ContextWriter(ctx)
+	for _, t := range killed {
+		w.Event(progress.KillingEvent(taskID(t.arn)))
+		if err := b.aws.StopTask(ctx, cluster, t.arn, reason); err != nil {
+			return nil, err
+		}
+	}
+
+	var arns []string
+	seen := map[string]bool{}
+	for _, t := range killed {
+		if err := b.waitTaskStopped(ctx, cluster, t.arn); err != nil {
+			return nil, err
+		}
+		w.Event(progress.KilledEvent(taskID(t.arn)))
+		if !seen[t.serviceArn] {
+			seen[t.serviceArn] = true
+			arns = append(arns, t.serviceArn)
+		}
+	}
+	// wait for ECS to replace killed tasks
+	return killed, b.aws.WaitServicesStable(ctx, cluster, arns)
+}
+
+func (b *ComposeECS) waitTaskStopped(ctx context.Context, cluster string, taskArn string) error {
+	ticker := time.NewTicker(killTaskPollInterval)
+	defer ticker.Stop()
+	for {
+		task, err := b.aws.DescribeTask(ctx, cluster, taskArn)
+		if err != nil {
+			return err
+		}
+		if aws.StringValue(task.LastStatus) == ecs.DesiredStatusStopped {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// matchTask tells if task ARN matches one of the task IDs or ARNs, or no filter is set
+func matchTask(taskArn string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if f == taskArn || f == taskID(taskArn) {
+			return true
+		}
+	}
+	return false
+}
+
+func taskID(taskArn string) string {
+	return taskArn[strings.LastIndex(taskArn, "/")+1:]
+}
+
+func checkUnsupportedKillOptions(ctx context.Context, o api.KillOptions) error {
+	var errs error
+	checks := []struct {
+		toCheck, expected interface{}
+		option            string
+	}{
+		{o.RemoveOrphans, false, "remove-orphans"},
+		{o.Signal, "SIGKILL", "signal"},
+	}
+	for _, c := range checks {
+		errs = utils.CheckUnsupported(ctx, errs, c.toCheck, c.expected, "kill", c.option)
+	}
+	return errs
+}
@@ -0,0 +1,86 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestKillTask(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+  bar:
+    image: hello_world
+`)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().ListStackResources(gomock.Any(), project.Name).Return(stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:foo"},
+		{LogicalID: "BarService", Type: awsTypeService, ARN: "arn:bar"},
+	}, nil)
+	m.EXPECT().DescribeServiceTasks(gomock.Any(), "cluster", project.Name, "foo").Return([]api.ContainerSummary{
+		{ID: "arn:aws:ecs:eu-west-3:123456789012:task/cluster/111", State: "Running"},
+		{ID: "arn:aws:ecs:eu-west-3:123456789012:task/cluster/222", State: "Running"},
+		{ID: "arn:aws:ecs:eu-west-3:123456789012:task/cluster/333", State: "Stopped"},
+	}, nil)
+	m.EXPECT().StopTask(gomock.Any(), "cluster", "arn:aws:ecs:eu-west-3:123456789012:task/cluster/222", "memory leak").Return(nil)
+	m.EXPECT().DescribeTask(gomock.Any(), "cluster", "arn:aws:ecs:eu-west-3:123456789012:task/cluster/222").Return(&ecs.Task{
+		LastStatus: aws.String(ecs.DesiredStatusStopped),
+	}, nil)
+	m.EXPECT().WaitServicesStable(gomock.Any(), "cluster", []string{"arn:foo"}).Return(nil)
+
+	backend := &ComposeECS{aws: m}
+	killed, err := backend.kill(context.TODO(), "cluster", project, []string{"foo"}, KillOptions{
+		Reason: "memory leak",
+		Tasks:  []string{"222"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(killed), 1)
+	assert.Equal(t, killed[0].arn, "arn:aws:ecs:eu-west-3:123456789012:task/cluster/222")
+	assert.Equal(t, killed[0].serviceArn, "arn:foo")
+}
+
+func TestKillUnknownTask(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+`)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().ListStackResources(gomock.Any(), project.Name).Return(stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:foo"},
+	}, nil)
+	m.EXPECT().DescribeServiceTasks(gomock.Any(), "cluster", project.Name, "foo").Return([]api.ContainerSummary{
+		{ID: "arn:aws:ecs:eu-west-3:123456789012:task/cluster/111", State: "Running"},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	_, err := backend.kill(context.TODO(), "cluster", project, nil, KillOptions{Tasks: []string{"222"}})
+	assert.Check(t, api.IsNotFoundError(err))
+}
@@ -19,35 +19,143 @@ package ecs
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/docker/compose/v2/pkg/api"
-
-	"github.com/docker/compose-ecs/utils"
 )
 
+// stackDeleted is the status of a project which stack has been deleted
+const stackDeleted = "Deleted"
+
+// projectStack is a CloudFormation stack deployed for a compose project
+type projectStack struct {
+	api.Stack
+	LastUpdated time.Time
+}
+
+// ProjectSummary describes a compose project deployed on ECS
+type ProjectSummary struct {
+	Name           string
+	Status         string
+	Reason         string `json:",omitempty"`
+	Services       int
+	Running        int
+	Desired        int
+	LastDeployment time.Time
+}
+
 func (b *ComposeECS) List(ctx context.Context, opts api.ListOptions) ([]api.Stack, error) {
-	if err := checkUnsupportedListOptions(ctx, opts); err != nil {
+	stacks, err := b.listStacks(ctx, opts.All)
+	if err != nil {
 		return nil, err
 	}
-	stacks, err := b.aws.ListStacks(ctx)
+	list := make([]api.Stack, len(stacks))
+	for i, stack := range stacks {
+		list[i] = stack.Stack
+	}
+	return list, nil
+}
+
+// ListProjects returns deployed projects with their services replicas. Set all to include failed and deleted projects
+func (b *ComposeECS) ListProjects(ctx context.Context, all bool) ([]ProjectSummary, error) {
+	stacks, err := b.listStacks(ctx, all)
 	if err != nil {
 		return nil, err
 	}
+	projects := make([]ProjectSummary, len(stacks))
+	for i, stack := range stacks {
+		projects[i] = ProjectSummary{
+			Name:           stack.Name,
+			Status:         stack.Status,
+			Reason:         stack.Reason,
+			LastDeployment: stack.LastUpdated,
+		}
+		if stack.Status == stackDeleted {
+			continue
+		}
+		if err := b.countReplicas(ctx, &projects[i]); err != nil {
+			return nil, err
+		}
+	}
+	return projects, nil
+}
 
-	for _, stack := range stacks {
+func (b *ComposeECS) listStacks(ctx context.Context, all bool) ([]projectStack, error) {
+	stacks, err := b.aws.ListStacks(ctx, all)
+	if err != nil {
+		return nil, err
+	}
+	for i, stack := range stacks {
 		if stack.Status == api.STARTING {
 			if err := b.checkStackState(ctx, stack.Name); err != nil {
-				stack.Status = api.FAILED
-				stack.Reason = err.Error()
+				stacks[i].Status = api.FAILED
+				stacks[i].Reason = err.Error()
 			}
 		}
 	}
 	return stacks, nil
+}
 
+// countReplicas sets the number of services and their running and desired tasks for project
+func (b *ComposeECS) countReplicas(ctx context.Context, project *ProjectSummary) error {
+	resources, err := b.aws.ListStackResources(ctx, project.Name)
+	if err != nil {
+		return err
+	}
+	var arns []string
+	for _, r := range resources {
+		if r.Type != awsTypeService {
+			continue
+		}
+		project.Services++
+		if r.ARN != "" {
+			arns = append(arns, r.ARN)
+		}
+	}
+	if len(arns) == 0 {
+		return nil
+	}
+	cluster, err := b.aws.GetStackClusterID(ctx, project.Name)
+	if err != nil {
+		return err
+	}
+	services, err := b.aws.DescribeServices(ctx, cluster, arns)
+	if err != nil {
+		return err
+	}
+	for _, s := range services {
+		project.Running += int(aws.Int64Value(s.RunningCount))
+		project.Desired += int(aws.Int64Value(s.DesiredCount))
+	}
+	return nil
 }
 
-func checkUnsupportedListOptions(ctx context.Context, o api.ListOptions) error {
-	return utils.CheckUnsupported(ctx, nil, o.All, false, "ls", "all")
+// stackStatus maps a CloudFormation stack status to a compose project status
+func stackStatus(status string) string {
+	switch status {
+	case cloudformation.StackStatusCreateInProgress:
+		return api.STARTING
+	case cloudformation.StackStatusDeleteInProgress:
+		return api.REMOVING
+	case cloudformation.StackStatusDeleteComplete:
+		return stackDeleted
+	case cloudformation.StackStatusUpdateInProgress,
+		cloudformation.StackStatusUpdateCompleteCleanupInProgress,
+		cloudformation.StackStatusUpdateRollbackInProgress,
+		cloudformation.StackStatusUpdateRollbackCompleteCleanupInProgress,
+		cloudformation.StackStatusReviewInProgress:
+		return api.UPDATING
+	case cloudformation.StackStatusRollbackInProgress,
+		cloudformation.StackStatusRollbackComplete:
+		return api.FAILED
+	}
+	if strings.HasSuffix(status, "_FAILED") {
+		return api.FAILED
+	}
+	return api.RUNNING
 }
 
 func (b *ComposeECS) checkStackState(ctx context.Context, name string) error {
@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestListProjects(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+
+	deployed := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	m.EXPECT().ListStacks(gomock.Any(), true).Return([]projectStack{
+		{Stack: api.Stack{ID: "arn:stack/foo", Name: "foo", Status: api.RUNNING}, LastUpdated: deployed},
+		{Stack: api.Stack{ID: "arn:stack/bar", Name: "bar", Status: stackDeleted}, LastUpdated: deployed},
+	}, nil)
+	expectStackServices(m.EXPECT(), "foo")
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo", "arn:bar"}).Return([]*ecs.Service{
+		{ServiceArn: aws.String("arn:foo"), RunningCount: aws.Int64(1), DesiredCount: aws.Int64(2)},
+		{ServiceArn: aws.String("arn:bar"), RunningCount: aws.Int64(1), DesiredCount: aws.Int64(1)},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	projects, err := backend.ListProjects(context.TODO(), true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, projects, []ProjectSummary{
+		{Name: "foo", Status: api.RUNNING, Services: 2, Running: 2, Desired: 3, LastDeployment: deployed},
+		{Name: "bar", Status: stackDeleted, LastDeployment: deployed},
+	})
+}
+
+func TestStackStatus(t *testing.T) {
+	for status, expected := range map[string]string{
+		"CREATE_IN_PROGRESS":                  api.STARTING,
+		"CREATE_COMPLETE":                     api.RUNNING,
+		"CREATE_FAILED":                       api.FAILED,
+		"ROLLBACK_COMPLETE":                   api.FAILED,
+		"UPDATE_IN_PROGRESS":                  api.UPDATING,
+		"UPDATE_ROLLBACK_IN_PROGRESS":         api.UPDATING,
+		"UPDATE_ROLLBACK_COMPLETE":            api.RUNNING,
+		"DELETE_IN_PROGRESS":                  api.REMOVING,
+		"DELETE_FAILED":                       api.FAILED,
+		"DELETE_COMPLETE":                     stackDeleted,
+		"UPDATE_COMPLETE_CLEANUP_IN_PROGRESS": api.UPDATING,
+	} {
+		assert.Equal(t, stackStatus(status), expected, status)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// errLiveTailUnavailable is returned when CloudWatch Logs Live Tail can't be used, typically because the region doesn't support it or
+// the StartLiveTail permission isn't granted, and logs have to be polled
+var errLiveTailUnavailable = errors.New("CloudWatch Logs Live Tail is not available")
+
+// liveTailMaxPrefixes is the maximum number of log stream prefixes a Live Tail session accepts
+const liveTailMaxPrefixes = 100
+
+// liveTailUpdate is a batch of log events received by a Live Tail session, or the error which ended it
+type liveTailUpdate struct {
+	Events []logStreamEvent
+	// Sampled is set when CloudWatch dropped events, as the session exceeded the Live Tail rate
+	Sampled bool
+	Err     error
+}
+
+// StartLiveTail starts a Live Tail session for log streams in group with given prefixes. It returns once the session has started, and
+// updates are sent until the session ends, which CloudWatch enforces after 3 hours
+func (s sdk) StartLiveTail(ctx context.Context, group string, prefixes []string) (<-chan liveTailUpdate, error) {
+	if len(prefixes) > liveTailMaxPrefixes {
+		return nil, errLiveTailUnavailable
+	}
+	groupArn, err := s.getLogGroupArn(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	input := &cloudwatchlogs.StartLiveTailInput{
+		LogGroupIdentifiers: aws.StringSlice([]string{groupArn}),
+	}
+	if len(prefixes) > 0 {
+		input.LogStreamNamePrefixes = aws.StringSlice(prefixes)
+	}
+	response, err := s.CW.StartLiveTailWithContext(ctx, input)
+	if err != nil {
+		return nil, liveTailError(err)
+	}
+
+	stream := response.GetStream()
+	if err := waitLiveTailSession(ctx, stream); err != nil {
+		stream.Close() //nolint:errcheck
+		return nil, liveTailError(err)
+	}
+
+	updates := make(chan liveTailUpdate)
+	go func() {
+		defer close(updates)
+		defer stream.Close() //nolint:errcheck
+		for {
+			var event cloudwatchlogs.StartLiveTailResponseStreamEvent
+			var ok bool
+			select {
+			case event, ok = <-stream.Events():
+			case <-ctx.Done():
+				return
+			}
+			if !ok {
+				err := stream.Err()
+				if err == nil {
+					err = errors.New("Live Tail session closed")
+				}
+				sendLiveTailUpdate(ctx, updates, liveTailUpdate{Err: err})
+				return
+			}
+			update, ok := event.(*cloudwatchlogs.LiveTailSessionUpdate)
+			if !ok {
+				continue
+			}
+			events := make([]logStreamEvent, len(update.SessionResults))
+			for i, r := range update.SessionResults {
+				events[i] = logStreamEvent{
+					Stream:        aws.StringValue(r.LogStreamName),
+					Message:       aws.StringValue(r.Message),
+					Timestamp:     aws.Int64Value(r.Timestamp),
+					IngestionTime: aws.Int64Value(r.IngestionTime),
+				}
+			}
+			var sampled bool
+			if update.SessionMetadata != nil {
+				sampled = aws.BoolValue(update.SessionMetadata.Sampled)
+			}
+			if !sendLiveTailUpdate(ctx, updates, liveTailUpdate{Events: events, Sampled: sampled}) {
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// waitLiveTailSession waits for the sessionStart event, which CloudWatch sends once the session receives log events
+func waitLiveTailSession(ctx context.Context, stream *cloudwatchlogs.StartLiveTailEventStream) error {
+	select {
+	case event, ok := <-stream.Events():
+		if !ok {
+			if err := stream.Err(); err != nil {
+				return err
+			}
+			return errors.New("Live Tail session closed")
+		}
+		if _, ok := event.(*cloudwatchlogs.LiveTailSessionStart); !ok {
+			return errors.Errorf("unexpected Live Tail event %T", event)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// liveTailError converts errors telling Live Tail isn't available to this client into errLiveTailUnavailable
+func liveTailError(err error) error {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case cloudwatchlogs.ErrCodeAccessDeniedException, "UnknownOperationException", "UnrecognizedClientException", request.ErrCodeRequestError:
+			return errors.Wrap(errLiveTailUnavailable, aerr.Message())
+		}
+	}
+	return err
+}
+
+// getLogGroupArn returns the ARN of log group, as Live Tail doesn't accept a name
+func (s sdk) getLogGroupArn(ctx context.Context, group string) (string, error) {
+	groups, err := s.CW.DescribeLogGroupsWithContext(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(group),
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, g := range groups.LogGroups {
+		if aws.StringValue(g.LogGroupName) == group {
+			return strings.TrimSuffix(aws.StringValue(g.Arn), ":*"), nil
+		}
+	}
+	return "", errors.Wrapf(api.ErrNotFound, "log group %s", group)
+}
+
+func sendLiveTailUpdate(ctx context.Context, updates chan<- liveTailUpdate, update liveTailUpdate) bool {
+	select {
+	case updates <- update:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -18,36 +18,110 @@ package ecs
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/docker/compose/v2/pkg/api"
+	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/pkg/errors"
 
 	"github.com/docker/compose-ecs/utils"
 )
 
+// logFilter selects the log events to retrieve from CloudWatch
+type logFilter struct {
+	Since  time.Time
+	Until  time.Time
+	Tail   int // number of events per log stream, negative for all
+	Follow bool
+}
+
 func (b *ComposeECS) Logs(ctx context.Context, projectName string, consumer api.LogConsumer, options api.LogOptions) error {
-	if err := checkUnsupportedLogOptions(ctx, options); err != nil {
+	filter, err := toLogFilter(options, time.Now())
+	if err != nil {
 		return err
 	}
 	if len(options.Services) > 0 {
 		consumer = utils.FilteredLogConsumer(consumer, options.Services)
 	}
-	err := b.aws.GetLogs(ctx, projectName, consumer.Log, options.Follow)
-	return err
+	tail := newLogTail(b.aws, logGroupName(projectName), logStreamPrefixes(projectName, options.Services), filter, func(event logStreamEvent) {
+		service, task, ok := logStreamSource(event.Stream)
+		if !ok {
+			return
+		}
+		message := event.Message
+		if options.Timestamps {
+			timestamp := aws.MillisecondsTimeValue(aws.Int64(event.Timestamp))
+			message = fmt.Sprintf("%s %s", timestamp.UTC().Format(time.RFC3339Nano), message)
+		}
+		consumer.Log(fmt.Sprintf("%s/%s", service, task), service, message)
+	})
+	return tail.run(ctx)
+}
+
+// logGroupName is the CloudWatch log group containers of project log to
+func logGroupName(project string) string {
+	return fmt.Sprintf("/docker-compose/%s", project)
+}
+
+// logStreamPrefixes selects log streams of services, as the awslogs driver names them `project/service/task`
+func logStreamPrefixes(project string, services []string) []string {
+	var prefixes []string
+	for _, service := range services {
+		prefixes = append(prefixes, fmt.Sprintf("%s/%s/", project, service))
+	}
+	if len(prefixes) == 0 {
+		prefixes = []string{project + "/"}
+	}
+	return prefixes
 }
 
-func checkUnsupportedLogOptions(ctx context.Context, o api.LogOptions) error {
-	var errs error
-	checks := []struct {
-		toCheck, expected interface{}
-		option            string
-	}{
-		{o.Since, "", "since"},
-		{o.Tail, "all", "tail"},
-		{o.Timestamps, false, "timestamps"},
-		{o.Until, "", "until"},
-	}
-	for _, c := range checks {
-		errs = utils.CheckUnsupported(ctx, errs, c.toCheck, c.expected, "logs", c.option)
-	}
-	return errs
+// logStreamSource parses service and task from a log stream name
+func logStreamSource(stream string) (string, string, bool) {
+	p := strings.Split(stream, "/")
+	if len(p) != 3 || p[1] == "" || p[2] == "" {
+		return "", "", false
+	}
+	return p[1], p[2], true
+}
+
+func toLogFilter(options api.LogOptions, now time.Time) (logFilter, error) {
+	filter := logFilter{
+		Tail:   -1,
+		Follow: options.Follow,
+	}
+	var err error
+	if options.Since != "" {
+		if filter.Since, err = parseLogTime(options.Since, now); err != nil {
+			return filter, errors.Wrap(err, "invalid value for --since")
+		}
+	}
+	if options.Until != "" {
+		if filter.Until, err = parseLogTime(options.Until, now); err != nil {
+			return filter, errors.Wrap(err, "invalid value for --until")
+		}
+	}
+	if options.Tail != "" && options.Tail != "all" {
+		filter.Tail, err = strconv.Atoi(options.Tail)
+		if err != nil || filter.Tail < 0 {
+			return filter, fmt.Errorf("invalid value for --tail: %q", options.Tail)
+		}
+	}
+	return filter, nil
+}
+
+// parseLogTime parses a timestamp or a duration relative to now, as docker logs does
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	ts, err := timetypes.GetTimestamp(value, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sec, nsec, err := timetypes.ParseTimestamps(ts, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, nsec), nil
 }
@@ -0,0 +1,144 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestToLogFilter(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	filter, err := toLogFilter(api.LogOptions{
+		Since:  "10m",
+		Until:  "2020-10-01T11:55:00Z",
+		Tail:   "20",
+		Follow: true,
+	}, now)
+	assert.NilError(t, err)
+	assert.Check(t, filter.Since.Equal(now.Add(-10*time.Minute)))
+	assert.Check(t, filter.Until.Equal(now.Add(-5*time.Minute)))
+	assert.Equal(t, filter.Tail, 20)
+	assert.Check(t, filter.Follow)
+
+	filter, err = toLogFilter(api.LogOptions{Tail: "all"}, now)
+	assert.NilError(t, err)
+	assert.Check(t, filter.Since.IsZero())
+	assert.Equal(t, filter.Tail, -1)
+
+	_, err = toLogFilter(api.LogOptions{Tail: "-3"}, now)
+	assert.ErrorContains(t, err, "invalid value for --tail")
+}
+
+func TestLogStreamSource(t *testing.T) {
+	container, service, ok := logStreamSource("project/foo/0123456789")
+	assert.Check(t, ok)
+	assert.Equal(t, container, "foo")
+	assert.Equal(t, service, "0123456789")
+
+	_, _, ok = logStreamSource("unexpected")
+	assert.Check(t, !ok)
+}
+
+func TestLogTailPerStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+
+	m.EXPECT().DescribeLogStreams(gomock.Any(), "/docker-compose/test", "test/foo/").Return([]string{"test/foo/1", "test/foo/2"}, nil)
+	m.EXPECT().GetLogStreamEvents(gomock.Any(), "/docker-compose/test", "test/foo/1", logStreamQuery{FromTail: true, Limit: 1}).Return([]logStreamEvent{
+		{Stream: "test/foo/1", Message: "one", Timestamp: 3, IngestionTime: 3},
+	}, aws.String("f/1"), nil)
+	m.EXPECT().GetLogStreamEvents(gomock.Any(), "/docker-compose/test", "test/foo/1", logStreamQuery{Token: aws.String("f/1")}).Return(nil, aws.String("f/1"), nil)
+	m.EXPECT().GetLogStreamEvents(gomock.Any(), "/docker-compose/test", "test/foo/2", logStreamQuery{FromTail: true, Limit: 1}).Return([]logStreamEvent{
+		{Stream: "test/foo/2", Message: "two", Timestamp: 2, IngestionTime: 2},
+	}, aws.String("f/2"), nil)
+	m.EXPECT().GetLogStreamEvents(gomock.Any(), "/docker-compose/test", "test/foo/2", logStreamQuery{Token: aws.String("f/2")}).Return(nil, aws.String("f/2"), nil)
+
+	var messages []string
+	tail := newLogTail(m, logGroupName("test"), logStreamPrefixes("test", []string{"foo"}), logFilter{Tail: 1}, func(event logStreamEvent) {
+		messages = append(messages, event.Message)
+	})
+	err := tail.run(context.TODO())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, messages, []string{"two", "one"})
+}
+
+func TestLogTailLiveDeduplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+
+	const group, stream = "/docker-compose/test", "test/foo/1"
+	m.EXPECT().DescribeLogStreams(gomock.Any(), group, "test/").Return([]string{stream}, nil).Times(2)
+	gomock.InOrder(
+		m.EXPECT().GetLogStreamEvents(gomock.Any(), group, stream, logStreamQuery{}).Return([]logStreamEvent{
+			{Stream: stream, Message: "history", Timestamp: 1, IngestionTime: 100},
+		}, aws.String("f/1"), nil),
+		m.EXPECT().GetLogStreamEvents(gomock.Any(), group, stream, logStreamQuery{Token: aws.String("f/1")}).Return(nil, aws.String("f/1"), nil),
+		// events ingested before the Live Tail session started
+		m.EXPECT().GetLogStreamEvents(gomock.Any(), group, stream, logStreamQuery{Token: aws.String("f/1")}).Return([]logStreamEvent{
+			{Stream: stream, Message: "catch up", Timestamp: 2, IngestionTime: 105},
+		}, aws.String("f/2"), nil),
+		m.EXPECT().GetLogStreamEvents(gomock.Any(), group, stream, logStreamQuery{Token: aws.String("f/2")}).Return(nil, aws.String("f/2"), nil),
+	)
+	updates := make(chan liveTailUpdate, 1)
+	updates <- liveTailUpdate{Events: []logStreamEvent{
+		{Stream: stream, Message: "catch up", Timestamp: 2, IngestionTime: 105},
+		{Stream: stream, Message: "live", Timestamp: 3, IngestionTime: 106},
+	}}
+	close(updates)
+	m.EXPECT().StartLiveTail(gomock.Any(), group, []string{"test/"}).Return(updates, nil)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	var messages []string
+	tail := newLogTail(m, group, logStreamPrefixes("test", nil), logFilter{Tail: -1, Follow: true}, func(event logStreamEvent) {
+		messages = append(messages, event.Message)
+		if event.Message == "live" {
+			cancel()
+		}
+	})
+	err := tail.run(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, messages, []string{"history", "catch up", "live"})
+}
+
+func TestLogStreamStateDeduplicate(t *testing.T) {
+	state := &logStreamState{}
+	state.record([]logStreamEvent{
+		{Message: "a", Timestamp: 1, IngestionTime: 10},
+		{Message: "b", Timestamp: 2, IngestionTime: 10},
+	})
+	fresh := state.deduplicate([]logStreamEvent{
+		{Message: "a", Timestamp: 1, IngestionTime: 10},
+		{Message: "b", Timestamp: 2, IngestionTime: 10},
+		{Message: "b", Timestamp: 2, IngestionTime: 10},
+		{Message: "c", Timestamp: 3, IngestionTime: 11},
+	})
+	// identical lines are only dropped as many times as they have been emitted
+	assert.DeepEqual(t, fresh, []logStreamEvent{
+		{Message: "b", Timestamp: 2, IngestionTime: 10},
+		{Message: "c", Timestamp: 3, IngestionTime: 11},
+	})
+}
@@ -0,0 +1,111 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	logsQueryPollInterval = time.Second
+	defaultLogsQuerySince = "1h"
+)
+
+// LogQueryOptions configures a CloudWatch Logs Insights query
+type LogQueryOptions struct {
+	Query    string
+	Services []string
+	Since    string
+	Until    string
+}
+
+// LogQueryField is a field of a Logs Insights query result
+type LogQueryField struct {
+	Field string
+	Value string
+}
+
+// LogQueryRow is a Logs Insights query result, with fields in query order
+type LogQueryRow []LogQueryField
+
+// QueryLogs runs a Logs Insights query against project log group, restricted to log streams of selected services
+func (b *ComposeECS) QueryLogs(ctx context.Context, project string, options LogQueryOptions) ([]LogQueryRow, error) {
+	now := time.Now()
+	if options.Since == "" {
+		options.Since = defaultLogsQuerySince
+	}
+	start, err := parseLogTime(options.Since, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid value for --since")
+	}
+	end := now
+	if options.Until != "" {
+		if end, err = parseLogTime(options.Until, now); err != nil {
+			return nil, errors.Wrap(err, "invalid value for --until")
+		}
+	}
+
+	id, err := b.aws.StartLogsQuery(ctx, logGroupName(project), scopeLogsQuery(project, options.Services, options.Query), start, end)
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(logsQueryPollInterval)
+	defer ticker.Stop()
+	for {
+		status, rows, err := b.aws.GetLogsQueryResults(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		switch status {
+		case cloudwatchlogs.QueryStatusComplete:
+			return rows, nil
+		case cloudwatchlogs.QueryStatusFailed, cloudwatchlogs.QueryStatusCancelled, cloudwatchlogs.QueryStatusTimeout:
+			return nil, fmt.Errorf("logs query %s: %s", id, strings.ToLower(status))
+		}
+		select {
+		case <-ctx.Done():
+			// queries keep running, and count against concurrent queries quota, until stopped
+			if err := b.aws.StopLogsQuery(context.Background(), id); err != nil {
+				logrus.Warnf("failed to stop logs query %s: %s", id, err)
+			}
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// scopeLogsQuery prepends query with a filter on log streams of services
+func scopeLogsQuery(project string, services []string, query string) string {
+	prefixes := make([]string, len(services))
+	for i, s := range services {
+		prefixes[i] = regexp.QuoteMeta(s)
+	}
+	pattern := regexp.QuoteMeta(project) + "/"
+	if len(prefixes) > 0 {
+		pattern += "(" + strings.Join(prefixes, "|") + ")/"
+	}
+	pattern = strings.ReplaceAll(pattern, "/", `\/`)
+	return fmt.Sprintf("filter @logStream like /^%s/ | %s", pattern, query)
+}
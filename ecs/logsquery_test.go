@@ -0,0 +1,59 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestScopeLogsQuery(t *testing.T) {
+	query := scopeLogsQuery("test", []string{"foo", "bar.1"}, "fields @message | filter @message like /ERROR/")
+	assert.Equal(t, query, `filter @logStream like /^test\/(foo|bar\.1)\// | fields @message | filter @message like /ERROR/`)
+
+	query = scopeLogsQuery("test", nil, "stats count(*)")
+	assert.Equal(t, query, `filter @logStream like /^test\// | stats count(*)`)
+}
+
+func TestQueryLogs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+
+	m.EXPECT().StartLogsQuery(gomock.Any(), "/docker-compose/test", `filter @logStream like /^test\/(foo)\// | fields @message`, gomock.Any(), gomock.Any()).Return("query-id", nil)
+	gomock.InOrder(
+		m.EXPECT().GetLogsQueryResults(gomock.Any(), "query-id").Return(cloudwatchlogs.QueryStatusRunning, nil, nil),
+		m.EXPECT().GetLogsQueryResults(gomock.Any(), "query-id").Return(cloudwatchlogs.QueryStatusComplete, []LogQueryRow{
+			{{Field: "@message", Value: "ERROR something went wrong"}},
+		}, nil),
+	)
+
+	backend := &ComposeECS{aws: m}
+	rows, err := backend.QueryLogs(context.TODO(), "test", LogQueryOptions{
+		Query:    "fields @message",
+		Services: []string{"foo"},
+		Since:    "2h",
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, rows, []LogQueryRow{
+		{{Field: "@message", Value: "ERROR something went wrong"}},
+	})
+}
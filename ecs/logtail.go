@@ -0,0 +1,363 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// logStreamsConcurrency is the maximum number of log streams read concurrently, so we don't exceed GetLogEvents quota
+	logStreamsConcurrency = 8
+	logPollInterval       = 2 * time.Second
+	logDiscoveryInterval  = 10 * time.Second
+)
+
+// logStreamQuery selects the events to read from a log stream. Time range and limit only apply when Token is not set
+type logStreamQuery struct {
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+	FromTail bool
+	Token    *string
+}
+
+// logStreamEvent is a CloudWatch log event, timestamps are in milliseconds
+type logStreamEvent struct {
+	Stream        string
+	Message       string
+	Timestamp     int64
+	IngestionTime int64
+}
+
+func (e logStreamEvent) key() string {
+	return fmt.Sprintf("%d/%d/%s", e.Timestamp, e.IngestionTime, e.Message)
+}
+
+// logStreamState tracks the position in a log stream, and the events already emitted so they are not emitted twice when switching
+// between polling and Live Tail
+type logStreamState struct {
+	token *string
+	// watermark is the highest ingestion time of emitted events
+	watermark int64
+	// emitted counts events emitted with watermark ingestion time by key
+	emitted map[string]int
+}
+
+// record marks events as emitted
+func (s *logStreamState) record(events []logStreamEvent) {
+	watermark := s.watermark
+	for _, e := range events {
+		if e.IngestionTime > watermark {
+			watermark = e.IngestionTime
+		}
+	}
+	if watermark > s.watermark || s.emitted == nil {
+		s.watermark = watermark
+		s.emitted = map[string]int{}
+	}
+	for _, e := range events {
+		if e.IngestionTime == s.watermark {
+			s.emitted[e.key()]++
+		}
+	}
+}
+
+// deduplicate returns events which have not been emitted yet, and records them
+func (s *logStreamState) deduplicate(events []logStreamEvent) []logStreamEvent {
+	emitted := map[string]int{}
+	for k, v := range s.emitted {
+		emitted[k] = v
+	}
+	var fresh []logStreamEvent
+	for _, e := range events {
+		if e.IngestionTime < s.watermark {
+			continue
+		}
+		if e.IngestionTime == s.watermark && emitted[e.key()] > 0 {
+			emitted[e.key()]--
+			continue
+		}
+		fresh = append(fresh, e)
+	}
+	s.record(fresh)
+	return fresh
+}
+
+// logTail reads log streams of a log group. Streams are read with GetLogEvents forward tokens, so events are never read twice, and
+// followed using a Live Tail session when available
+type logTail struct {
+	aws      API
+	group    string
+	prefixes []string
+	filter   logFilter
+	emit     func(event logStreamEvent)
+	streams  map[string]*logStreamState
+	// live is set once events have been received by a Live Tail session, so stream tokens lag behind emitted events
+	live bool
+}
+
+func newLogTail(client API, group string, prefixes []string, filter logFilter, emit func(event logStreamEvent)) *logTail {
+	return &logTail{
+		aws:      client,
+		group:    group,
+		prefixes: prefixes,
+		filter:   filter,
+		emit:     emit,
+		streams:  map[string]*logStreamState{},
+	}
+}
+
+func (t *logTail) run(ctx context.Context) error {
+	if err := t.discover(ctx); err != nil {
+		return err
+	}
+	events, err := t.read(ctx, true)
+	if err != nil {
+		return err
+	}
+	t.emitSorted(events)
+	if !t.filter.Follow || t.ended() {
+		return nil
+	}
+
+	if t.filter.Until.IsZero() {
+		err := t.liveTail(ctx)
+		if !errors.Is(err, errLiveTailUnavailable) {
+			return err
+		}
+		logrus.Debugf("%s, polling log streams", err)
+	}
+	return t.poll(ctx)
+}
+
+// discover registers log streams created since last call, typically by new tasks
+func (t *logTail) discover(ctx context.Context) error {
+	for _, prefix := range t.prefixes {
+		streams, err := t.aws.DescribeLogStreams(ctx, t.group, prefix)
+		if err != nil {
+			return err
+		}
+		for _, stream := range streams {
+			if _, ok := t.streams[stream]; !ok {
+				t.streams[stream] = &logStreamState{}
+			}
+		}
+	}
+	return nil
+}
+
+// read reads all log streams from their current position, with bounded concurrency. On first read, tail filter applies
+func (t *logTail) read(ctx context.Context, first bool) ([]logStreamEvent, error) {
+	names := make([]string, 0, len(t.streams))
+	for name := range t.streams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	events := make([][]logStreamEvent, len(names))
+	tokens := make([]*string, len(names))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(logStreamsConcurrency)
+	for i, name := range names {
+		i, name, token := i, name, t.streams[name].token
+		eg.Go(func() error {
+			var err error
+			events[i], tokens[i], err = t.readStream(ctx, name, token, first)
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	var all []logStreamEvent
+	for i, name := range names {
+		state := t.streams[name]
+		state.token = tokens[i]
+		if t.live {
+			events[i] = state.deduplicate(events[i])
+		} else {
+			state.record(events[i])
+		}
+		all = append(all, events[i]...)
+	}
+	return all, nil
+}
+
+// readStream reads a log stream from token up to its end, and returns the token to read next events
+func (t *logTail) readStream(ctx context.Context, stream string, token *string, first bool) ([]logStreamEvent, *string, error) {
+	query := logStreamQuery{Token: token}
+	tail := -1
+	if token == nil {
+		query.Since = t.filter.Since
+		query.Until = t.filter.Until
+		if first && t.filter.Tail >= 0 {
+			tail = t.filter.Tail
+			// reading backward, the first page is the last events of stream, we only need a token when tail is 0
+			query.FromTail = true
+			query.Limit = tail
+			if tail == 0 {
+				query.Limit = 1
+			}
+		}
+	}
+
+	var events []logStreamEvent
+	for {
+		page, next, err := t.aws.GetLogStreamEvents(ctx, t.group, stream, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tail != 0 {
+			events = append(events, t.until(page)...)
+		}
+		if next == nil || aws.StringValue(next) == aws.StringValue(query.Token) {
+			return events, query.Token, nil
+		}
+		query = logStreamQuery{Token: next}
+		tail = -1
+	}
+}
+
+// until drops events after the until filter, as it doesn't apply to reads by token
+func (t *logTail) until(events []logStreamEvent) []logStreamEvent {
+	if t.filter.Until.IsZero() {
+		return events
+	}
+	until := aws.TimeUnixMilli(t.filter.Until)
+	var selected []logStreamEvent
+	for _, e := range events {
+		if e.Timestamp <= until {
+			selected = append(selected, e)
+		}
+	}
+	return selected
+}
+
+func (t *logTail) ended() bool {
+	return !t.filter.Until.IsZero() && time.Now().After(t.filter.Until)
+}
+
+func (t *logTail) emitSorted(events []logStreamEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp < events[j].Timestamp
+	})
+	for _, e := range events {
+		t.emit(e)
+	}
+}
+
+// poll reads log streams periodically, and discovers new ones
+func (t *logTail) poll(ctx context.Context) error {
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+	discovered := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if time.Since(discovered) > logDiscoveryInterval {
+			if err := t.discover(ctx); err != nil {
+				return err
+			}
+			discovered = time.Now()
+		}
+		events, err := t.read(ctx, false)
+		if err != nil {
+			return err
+		}
+		t.emitSorted(events)
+		if t.ended() {
+			return nil
+		}
+	}
+}
+
+// liveTail follows log streams with Live Tail sessions, renewed when CloudWatch ends them
+func (t *logTail) liveTail(ctx context.Context) error {
+	var warned bool
+	for {
+		updates, err := t.aws.StartLiveTail(ctx, t.group, t.prefixes)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		// Live Tail only delivers events ingested once session has started, so we read the ones ingested meanwhile
+		if err := t.discover(ctx); err != nil {
+			return err
+		}
+		events, err := t.read(ctx, false)
+		if err != nil {
+			return err
+		}
+		t.emitSorted(events)
+		t.live = true
+
+		for update := range updates {
+			if update.Err != nil {
+				logrus.Debugf("Live Tail session ended: %s", update.Err)
+				break
+			}
+			if update.Sampled && !warned {
+				logrus.Warn("CloudWatch Logs Live Tail is sampling log events, some lines are not displayed")
+				warned = true
+			}
+			t.emitSorted(t.deduplicate(update.Events))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logPollInterval):
+		}
+	}
+}
+
+// deduplicate drops events already emitted, grouping them by log stream
+func (t *logTail) deduplicate(events []logStreamEvent) []logStreamEvent {
+	byStream := map[string][]logStreamEvent{}
+	var names []string
+	for _, e := range events {
+		if _, ok := byStream[e.Stream]; !ok {
+			names = append(names, e.Stream)
+		}
+		byStream[e.Stream] = append(byStream[e.Stream], e)
+	}
+	var fresh []logStreamEvent
+	for _, name := range names {
+		state, ok := t.streams[name]
+		if !ok {
+			// stream of a new task, we'll read it from start if the session is renewed
+			state = &logStreamState{}
+			t.streams[name] = state
+		}
+		fresh = append(fresh, state.deduplicate(byStream[name])...)
+	}
+	return fresh
+}
@@ -21,11 +21,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/awslabs/goformation/v4/cloudformation"
 	"github.com/sanathkr/go-yaml"
 )
 
-func marshall(template *cloudformation.Template, format string) ([]byte, error) {
+func marshall(template *stackTemplate, format string) ([]byte, error) {
 	var (
 		source    func() ([]byte, error)
 		marshal   func(in interface{}) ([]byte, error)
@@ -74,5 +73,23 @@ func marshall(template *cloudformation.Template, format string) ([]byte, error)
 		}
 	}
 
+	if len(template.Hooks) > 0 {
+		// goformation doesn't support Hooks, we add the section using the same types as the unmarshalled template
+		raw, err := json.Marshal(template.Hooks)
+		if err != nil {
+			return nil, err
+		}
+		var hooks interface{}
+		if err := unmarshal(raw, &hooks); err != nil {
+			return nil, err
+		}
+		switch input := unmarshalled.(type) {
+		case map[interface{}]interface{}:
+			input["Hooks"] = hooks
+		case map[string]interface{}:
+			input["Hooks"] = hooks
+		}
+	}
+
 	return marshal(unmarshalled)
 }
@@ -0,0 +1,149 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/progress"
+)
+
+// orphanBucketMinAge is the age a template bucket must have to be an orphan, so we don't remove the one a concurrent `up` is using
+const orphanBucketMinAge = time.Hour
+
+// templateBucket is an S3 bucket created to upload a CloudFormation template too large to be sent inline
+type templateBucket struct {
+	Name    string
+	Created time.Time
+}
+
+// orphan is a resource created for a project which its current model doesn't use anymore
+type orphan struct {
+	kind   string
+	name   string
+	remove func(ctx context.Context) error
+}
+
+func (o orphan) String() string {
+	return fmt.Sprintf("%s %s", o.kind, o.name)
+}
+
+// listOrphans returns the resources tagged with project label not used by the current model: file systems of volumes which are not
+// declared anymore, leftover template buckets and task definition revisions not in inUse. File systems are only considered when
+// project is set, as we can't tell otherwise which volumes are declared.
+func (b *ComposeECS) listOrphans(ctx context.Context, projectName string, project *types.Project, inUse map[string]bool) ([]orphan, error) {
+	var orphans []orphan
+	if project != nil {
+		filesystems, err := b.projectFileSystems(ctx, projectName, project)
+		if err != nil {
+			return nil, err
+		}
+		for _, fs := range filesystems {
+			if _, ok := project.Volumes[fs.Volume]; ok {
+				continue
+			}
+			id := fs.ID
+			orphans = append(orphans, orphan{
+				kind: "EFS file system",
+				name: fmt.Sprintf("%s (volume %s)", fs.ID, fs.Volume),
+				remove: func(ctx context.Context) error {
+					return b.deleteFileSystem(ctx, id)
+				},
+			})
+		}
+	}
+
+	buckets, err := b.aws.ListTemplateBuckets(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range buckets {
+		if time.Since(bucket.Created) < orphanBucketMinAge {
+			continue
+		}
+		name := bucket.Name
+		orphans = append(orphans, orphan{
+			kind: "S3 bucket",
+			name: name,
+			remove: func(ctx context.Context) error {
+				return b.aws.DeleteBucket(ctx, name)
+			},
+		})
+	}
+
+	definitions, err := b.aws.ListProjectTaskDefinitions(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
+	for _, arn := range definitions {
+		if inUse[arn] {
+			continue
+		}
+		arn := arn
+		orphans = append(orphans, orphan{
+			kind: "Task definition",
+			name: arn,
+			remove: func(ctx context.Context) error {
+				return b.aws.DeregisterTaskDefinition(ctx, arn)
+			},
+		})
+	}
+	return orphans, nil
+}
+
+// deployedTaskDefinitions returns the task definition revisions used by project stack
+func (b *ComposeECS) deployedTaskDefinitions(ctx context.Context, projectName string) (map[string]bool, error) {
+	exists, err := b.aws.StackExists(ctx, projectName)
+	if err != nil || !exists {
+		return nil, err
+	}
+	resources, err := b.aws.ListStackResources(ctx, projectName)
+	if err != nil {
+		return nil, err
+	}
+	inUse := map[string]bool{}
+	for _, r := range resources {
+		if r.Type == awsTypeTaskDefinition {
+			inUse[r.ARN] = true
+		}
+	}
+	return inUse, nil
+}
+
+func (b *ComposeECS) removeOrphans(ctx context.Context, orphans []orphan) error {
+	w := progress.ContextWriter(ctx)
+	for _, o := range orphans {
+		w.Event(progress.RemovingEvent(o.String()))
+		if err := o.remove(ctx); err != nil {
+			w.Event(progress.ErrorEvent(o.String()))
+			return err
+		}
+		w.Event(progress.RemovedEvent(o.String()))
+	}
+	return nil
+}
+
+// printOrphans lists the orphans which would be removed by a dry run
+func printOrphans(w io.Writer, orphans []orphan) {
+	for _, o := range orphans {
+		fmt.Fprintf(w, "Would remove %s\n", o)
+	}
+}
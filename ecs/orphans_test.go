@@ -0,0 +1,73 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestListOrphans(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+volumes:
+  db:
+`)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().ListProjectFileSystems(gomock.Any(), t.Name()).Return([]projectFileSystem{
+		{Volume: "db", ID: "fs-db"},
+		{Volume: "cache", ID: "fs-cache"},
+	}, nil)
+	m.EXPECT().ListTemplateBuckets(gomock.Any(), t.Name()).Return([]templateBucket{
+		{Name: "com.docker.compose.old", Created: time.Now().Add(-2 * time.Hour)},
+		{Name: "com.docker.compose.new", Created: time.Now()},
+	}, nil)
+	m.EXPECT().ListProjectTaskDefinitions(gomock.Any(), t.Name()).Return([]string{
+		"arn:aws:ecs:eu-west-3:123456789012:task-definition/TestListOrphans-foo:2",
+		"arn:aws:ecs:eu-west-3:123456789012:task-definition/TestListOrphans-foo:1",
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	orphans, err := backend.listOrphans(context.TODO(), t.Name(), project, map[string]bool{
+		"arn:aws:ecs:eu-west-3:123456789012:task-definition/TestListOrphans-foo:2": true,
+	})
+	assert.NilError(t, err)
+	var names []string
+	for _, o := range orphans {
+		names = append(names, o.String())
+	}
+	assert.DeepEqual(t, names, []string{
+		"EFS file system fs-cache (volume cache)",
+		"S3 bucket com.docker.compose.old",
+		"Task definition arn:aws:ecs:eu-west-3:123456789012:task-definition/TestListOrphans-foo:1",
+	})
+
+	m.EXPECT().DeregisterTaskDefinition(gomock.Any(), "arn:aws:ecs:eu-west-3:123456789012:task-definition/TestListOrphans-foo:1").Return(nil)
+	assert.NilError(t, orphans[2].remove(context.TODO()))
+}
+
+func TestTaskDefinitionFamily(t *testing.T) {
+	assert.Equal(t, taskDefinitionFamily("arn:aws:ecs:eu-west-3:123456789012:task-definition/myproject-web:12"), "myproject-web")
+}
@@ -0,0 +1,38 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+)
+
+// Pause has no ECS equivalent for running tasks, so services are scaled down to zero like Stop does
+func (b *ComposeECS) Pause(ctx context.Context, project string, options api.PauseOptions) error {
+	return progress.Run(ctx, func(ctx context.Context) error {
+		return b.stop(ctx, project, options.Services)
+	})
+}
+
+// UnPause restores services scaled down by Pause
+func (b *ComposeECS) UnPause(ctx context.Context, project string, options api.PauseOptions) error {
+	return progress.Run(ctx, func(ctx context.Context) error {
+		return b.start(ctx, project, options.Services)
+	})
+}
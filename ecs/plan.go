@@ -0,0 +1,220 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/pkg/errors"
+)
+
+// changeSet is a CloudFormation change set, and the resource changes it applies
+type changeSet struct {
+	ID              string
+	Name            string
+	StackName       string
+	Status          string
+	ExecutionStatus string
+	StatusReason    string
+	Changes         []resourceChange
+}
+
+// empty tells change set has been rejected by CloudFormation as it has no change
+func (cs changeSet) empty() bool {
+	return cs.Status == cloudformation.ChangeSetStatusFailed && strings.HasPrefix(cs.StatusReason, noChangesStatusReason)
+}
+
+// resourceChange is the change of a stack resource. Replacement is "Conditional" when it depends on values only known on deployment
+type resourceChange struct {
+	Action      string
+	LogicalID   string
+	Type        string
+	Replacement string
+	Properties  []string
+}
+
+func (c resourceChange) replaced() bool {
+	return c.Replacement == cloudformation.ReplacementTrue || c.Replacement == cloudformation.ReplacementConditional
+}
+
+// Plan creates a change set to deploy project, and prints the changes it applies without executing it, so it can be reviewed before
+// `apply`. It fails when resources would be replaced.
+func (b *ComposeECS) Plan(ctx context.Context, project *types.Project) error {
+	return b.upDryRun(ctx, project, api.UpOptions{})
+}
+
+func (b *ComposeECS) upDryRun(ctx context.Context, project *types.Project, options api.UpOptions) error {
+	cs, err := b.plan(ctx, project)
+	if err != nil {
+		return err
+	}
+	printChangeSet(os.Stdout, cs)
+	if options.Create.RemoveOrphans {
+		inUse, err := b.deployedTaskDefinitions(ctx, project.Name)
+		if err != nil {
+			return err
+		}
+		orphans, err := b.listOrphans(ctx, project.Name, project, inUse)
+		if err != nil {
+			return err
+		}
+		printOrphans(os.Stdout, orphans)
+	}
+	return checkReplacements(cs)
+}
+
+func (b *ComposeECS) plan(ctx context.Context, project *types.Project) (changeSet, error) {
+	if err := b.aws.CheckRequirements(ctx, b.Region); err != nil {
+		return changeSet{}, err
+	}
+	template, err := b.Convert(ctx, project, api.ConvertOptions{
+		Format: "yaml",
+	})
+	if err != nil {
+		return changeSet{}, err
+	}
+	changeSetType, err := b.changeSetType(ctx, project.Name)
+	if err != nil {
+		return changeSet{}, err
+	}
+	if changeSetType == "" {
+		changeSetType = cloudformation.ChangeSetTypeCreate
+	}
+	id, err := b.aws.CreateChangeSet(ctx, project.Name, changeSetType, b.Region, template)
+	if err != nil {
+		return changeSet{}, err
+	}
+	return b.aws.DescribeChangeSet(ctx, project.Name, id)
+}
+
+// changeSetType returns the type of change set which deploys stack, or an empty string if stack doesn't exist. A stack created by a
+// change set which has not been executed yet is in REVIEW_IN_PROGRESS status, and has to be deployed by a creation change set.
+func (b *ComposeECS) changeSetType(ctx context.Context, name string) (string, error) {
+	exists, err := b.aws.StackExists(ctx, name)
+	if err != nil || !exists {
+		return "", err
+	}
+	status, err := b.aws.GetStackStatus(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if status == cloudformation.StackStatusReviewInProgress {
+		return cloudformation.ChangeSetTypeCreate, nil
+	}
+	return cloudformation.ChangeSetTypeUpdate, nil
+}
+
+// Apply executes a change set created by Plan, and waits for deployment to complete. Stack is only required when changeset is a name
+func (b *ComposeECS) Apply(ctx context.Context, stack string, changeset string) error {
+	if err := checkOnInterruptPolicy(b.UpOptions.OnInterrupt); err != nil {
+		return err
+	}
+	err := progress.Run(ctx, func(ctx context.Context) error {
+		return b.apply(ctx, stack, changeset)
+	})
+	var interrupted *deploymentInterrupted
+	if !errors.As(err, &interrupted) {
+		return err
+	}
+	return b.onInterrupt(ctx, interrupted)
+}
+
+func (b *ComposeECS) apply(ctx context.Context, stack string, changeset string) error {
+	cs, err := b.aws.DescribeChangeSet(ctx, stack, changeset)
+	if err != nil {
+		return err
+	}
+	if cs.empty() {
+		progress.ContextWriter(ctx).Event(progress.NewEvent(cs.StackName, progress.Done, "No changes"))
+		return nil
+	}
+	if cs.ExecutionStatus != cloudformation.ExecutionStatusAvailable {
+		err := fmt.Errorf("change set %s can't be executed, its status is %s", cs.Name, cs.ExecutionStatus)
+		if cs.StatusReason != "" {
+			err = errors.Wrap(err, cs.StatusReason)
+		}
+		return err
+	}
+
+	status, err := b.aws.GetStackStatus(ctx, cs.StackName)
+	if err != nil {
+		return err
+	}
+	operation := stackCreate
+	var previousEvents []string
+	if status != cloudformation.StackStatusReviewInProgress {
+		operation = stackUpdate
+		previousEvents, err = b.previousStackEvents(ctx, cs.StackName)
+		if err != nil {
+			return err
+		}
+	}
+	template, err := b.aws.GetTemplate(ctx, cs.StackName, cs.ID)
+	if err != nil {
+		return err
+	}
+	b.recordPendingDeployment(ctx, cs.StackName)
+	if err := b.aws.UpdateStack(ctx, cs.ID); err != nil {
+		return err
+	}
+	err = b.WaitStackCompletion(ctx, cs.StackName, operation, previousEvents...)
+	if ctx.Err() == context.Canceled {
+		return &deploymentInterrupted{stack: cs.StackName, operation: operation}
+	}
+	if err != nil {
+		return err
+	}
+	b.recordDeployment(ctx, cs.StackName, "", template)
+	return nil
+}
+
+func printChangeSet(w io.Writer, cs changeSet) {
+	if cs.empty() {
+		fmt.Fprintf(w, "No changes to stack %s\n", cs.StackName)
+		return
+	}
+	fmt.Fprintf(w, "Change set %s for stack %s\n", cs.ID, cs.StackName)
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "ACTION\tRESOURCE\tTYPE\tREPLACEMENT\tPROPERTIES")
+	for _, c := range cs.Changes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", c.Action, c.LogicalID, c.Type, c.Replacement, strings.Join(c.Properties, ", "))
+	}
+	tw.Flush() //nolint:errcheck
+}
+
+// checkReplacements fails if change set replaces resources, as this interrupts services and may lose data
+func checkReplacements(cs changeSet) error {
+	var replaced []string
+	for _, c := range cs.Changes {
+		if c.replaced() {
+			replaced = append(replaced, c.LogicalID)
+		}
+	}
+	if len(replaced) == 0 {
+		return nil
+	}
+	return fmt.Errorf("change set %s replaces resources: %s", cs.Name, strings.Join(replaced, ", "))
+}
@@ -0,0 +1,139 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+const testChangeSet = "arn:aws:cloudformation:eu-west-3:123456789012:changeSet/Update2020-01-01-00-00-00/0123456789"
+
+func TestToResourceChange(t *testing.T) {
+	change := toResourceChange(&cloudformation.ResourceChange{
+		Action:            aws.String(cloudformation.ChangeActionModify),
+		LogicalResourceId: aws.String("FooTaskDefinition"),
+		ResourceType:      aws.String(awsTypeTaskDefinition),
+		Replacement:       aws.String(cloudformation.ReplacementTrue),
+		Details: []*cloudformation.ResourceChangeDetail{
+			{Target: &cloudformation.ResourceTargetDefinition{Attribute: aws.String("Properties"), Name: aws.String("ContainerDefinitions")}},
+			{Target: &cloudformation.ResourceTargetDefinition{Attribute: aws.String("Properties"), Name: aws.String("ContainerDefinitions")}},
+			{Target: &cloudformation.ResourceTargetDefinition{Attribute: aws.String("Tags")}},
+		},
+	})
+	assert.DeepEqual(t, change, resourceChange{
+		Action:      "Modify",
+		LogicalID:   "FooTaskDefinition",
+		Type:        awsTypeTaskDefinition,
+		Replacement: "True",
+		Properties:  []string{"ContainerDefinitions", "Tags"},
+	})
+}
+
+func TestPrintChangeSet(t *testing.T) {
+	var b bytes.Buffer
+	printChangeSet(&b, changeSet{
+		ID:        testChangeSet,
+		StackName: "TestPrintChangeSet",
+		Changes: []resourceChange{
+			{Action: "Add", LogicalID: "BarService", Type: awsTypeService},
+			{Action: "Modify", LogicalID: "FooTaskDefinition", Type: awsTypeTaskDefinition, Replacement: "True", Properties: []string{"ContainerDefinitions", "Tags"}},
+		},
+	})
+	golden.Assert(t, b.String(), "change-set.golden")
+
+	b.Reset()
+	printChangeSet(&b, changeSet{
+		StackName:    "TestPrintChangeSet",
+		Status:       cloudformation.ChangeSetStatusFailed,
+		StatusReason: noChangesStatusReason + " Submit different information to create a change set.",
+	})
+	assert.Equal(t, b.String(), "No changes to stack TestPrintChangeSet\n")
+}
+
+func TestCheckReplacements(t *testing.T) {
+	cs := changeSet{
+		Name: "Update2020-01-01-00-00-00",
+		Changes: []resourceChange{
+			{Action: "Modify", LogicalID: "FooService", Replacement: "False"},
+			{Action: "Add", LogicalID: "BarService"},
+		},
+	}
+	assert.NilError(t, checkReplacements(cs))
+
+	cs.Changes = append(cs.Changes,
+		resourceChange{Action: "Modify", LogicalID: "LoadBalancer", Replacement: "True"},
+		resourceChange{Action: "Modify", LogicalID: "FooTCP80TargetGroup", Replacement: "Conditional"},
+	)
+	assert.Error(t, checkReplacements(cs), "change set Update2020-01-01-00-00-00 replaces resources: LoadBalancer, FooTCP80TargetGroup")
+}
+
+func TestApplyUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().DescribeChangeSet(gomock.Any(), "", testChangeSet).Return(changeSet{
+		ID:              testChangeSet,
+		StackName:       t.Name(),
+		Status:          cloudformation.ChangeSetStatusCreateComplete,
+		ExecutionStatus: cloudformation.ExecutionStatusAvailable,
+		Changes:         []resourceChange{{Action: "Modify", LogicalID: "FooService"}},
+	}, nil)
+	m.EXPECT().GetStackStatus(gomock.Any(), t.Name()).Return(cloudformation.StackStatusUpdateComplete, nil)
+	m.EXPECT().DescribeStackEvents(gomock.Any(), t.Name()).Return([]*cloudformation.StackEvent{
+		{EventId: aws.String("1")},
+	}, nil)
+	m.EXPECT().GetTemplate(gomock.Any(), t.Name(), testChangeSet).Return([]byte(testTemplate), nil)
+	expectCallerIdentity(m.EXPECT())
+	expectNoPendingDeployment(m.EXPECT(), t.Name())
+	m.EXPECT().UpdateStack(gomock.Any(), testChangeSet).Return(nil)
+	expectRecordDeployment(m.EXPECT(), t.Name(), "", 2)
+	m.EXPECT().GetStackID(gomock.Any(), t.Name()).Return("stack-id", nil)
+	m.EXPECT().WaitStackComplete(gomock.Any(), "stack-id", stackUpdate).Return(nil)
+	m.EXPECT().DescribeStackEvents(gomock.Any(), "stack-id").Return([]*cloudformation.StackEvent{
+		{EventId: aws.String("1")},
+	}, nil)
+
+	backend := &ComposeECS{aws: m, Region: "eu-west-3"}
+	err := backend.apply(context.TODO(), "", testChangeSet)
+	assert.NilError(t, err)
+}
+
+func TestApplyObsoleteChangeSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().DescribeChangeSet(gomock.Any(), t.Name(), "Update2020-01-01-00-00-00").Return(changeSet{
+		ID:              testChangeSet,
+		Name:            "Update2020-01-01-00-00-00",
+		StackName:       t.Name(),
+		Status:          cloudformation.ChangeSetStatusCreateComplete,
+		ExecutionStatus: cloudformation.ExecutionStatusObsolete,
+		Changes:         []resourceChange{{Action: "Modify", LogicalID: "FooService"}},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	err := backend.apply(context.TODO(), t.Name(), "Update2020-01-01-00-00-00")
+	assert.Error(t, err, "change set Update2020-01-01-00-00-00 can't be executed, its status is OBSOLETE")
+}
@@ -0,0 +1,141 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/pkg/errors"
+)
+
+// Port returns the load balancer DNS name and listener port service port is exposed by, or the task public IP when
+// service is not exposed by a load balancer
+func (b *ComposeECS) Port(ctx context.Context, project string, service string, port int, options api.PortOptions) (string, int, error) {
+	cluster, err := b.aws.GetStackClusterID(ctx, project)
+	if err != nil {
+		return "", 0, err
+	}
+	serviceArn, err := b.getServiceArn(ctx, project, service)
+	if err != nil {
+		return "", 0, err
+	}
+	services, err := b.aws.DescribeServices(ctx, cluster, []string{serviceArn})
+	if err != nil {
+		return "", 0, err
+	}
+	if len(services) == 0 {
+		return "", 0, errors.Wrapf(api.ErrNotFound, "service %s does not exist in cluster %s", serviceArn, cluster)
+	}
+	var targetGroups []string
+	loadBalancers, _ := serviceNetwork(services[0])
+	for _, lb := range loadBalancers {
+		targetGroups = append(targetGroups, aws.StringValue(lb.TargetGroupArn))
+	}
+	publishers, err := b.aws.GetTargetGroupPublishers(ctx, targetGroups)
+	if err != nil {
+		return "", 0, err
+	}
+	for _, tg := range targetGroups {
+		for _, p := range publishers[tg] {
+			if p.TargetPort != port || !matchProtocol(p.Protocol, options.Protocol) {
+				continue
+			}
+			host, _, err := net.SplitHostPort(p.URL)
+			if err != nil {
+				return "", 0, err
+			}
+			return host, p.PublishedPort, nil
+		}
+	}
+
+	taskArn, err := b.selectServiceTask(ctx, cluster, project, service, options.Index)
+	if err != nil {
+		return "", 0, err
+	}
+	task, err := b.aws.DescribeTask(ctx, cluster, taskArn)
+	if err != nil {
+		return "", 0, err
+	}
+	definition, _, err := b.aws.DescribeTaskDefinition(ctx, aws.StringValue(task.TaskDefinitionArn))
+	if err != nil {
+		return "", 0, err
+	}
+	container, err := getContainerDefinition(definition, service)
+	if err != nil {
+		return "", 0, err
+	}
+	if !hasPortMapping(container, port, options.Protocol) {
+		return "", 0, errors.Wrapf(api.ErrNotFound, "service %q doesn't expose port %d", service, port)
+	}
+	eni := taskNetworkInterface(task)
+	if eni == "" {
+		return "", 0, errors.Wrapf(api.ErrNotFound, "task %s has no network interface", taskID(taskArn))
+	}
+	ips, err := b.aws.GetPublicIPs(ctx, eni)
+	if err != nil {
+		return "", 0, err
+	}
+	ip, ok := ips[eni]
+	if !ok || ip == "" {
+		return "", 0, errors.Wrapf(api.ErrNotFound, "service %q is not exposed by a load balancer and task %s has no public IP", service, taskID(taskArn))
+	}
+	return ip, port, nil
+}
+
+// matchProtocol tells if a load balancer target group protocol (tcp, udp, tcp_udp, tls, http or https) serves requested protocol
+func matchProtocol(targetGroup string, protocol string) bool {
+	if protocol == "udp" {
+		return targetGroup == "udp" || targetGroup == "tcp_udp"
+	}
+	return targetGroup != "udp"
+}
+
+func hasPortMapping(container *ecs.ContainerDefinition, port int, protocol string) bool {
+	if protocol == "" {
+		protocol = ecs.TransportProtocolTcp
+	}
+	for _, m := range container.PortMappings {
+		p := aws.StringValue(m.Protocol)
+		if p == "" {
+			p = ecs.TransportProtocolTcp
+		}
+		if int(aws.Int64Value(m.ContainerPort)) == port && strings.EqualFold(p, protocol) {
+			return true
+		}
+	}
+	return false
+}
+
+// taskNetworkInterface returns the ID of the elastic network interface attached to an awsvpc task
+func taskNetworkInterface(task *ecs.Task) string {
+	for _, a := range task.Attachments {
+		if aws.StringValue(a.Type) != "ElasticNetworkInterface" {
+			continue
+		}
+		for _, d := range a.Details {
+			if aws.StringValue(d.Name) == "networkInterfaceId" {
+				return aws.StringValue(d.Value)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestPortFromLoadBalancer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().GetStackClusterID(gomock.Any(), t.Name()).Return("cluster", nil)
+	m.EXPECT().ListStackResources(gomock.Any(), t.Name()).Return(stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:foo"},
+	}, nil)
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{ServiceArn: aws.String("arn:foo"), LoadBalancers: []*ecs.LoadBalancer{{TargetGroupArn: aws.String("arn:tg")}}},
+	}, nil)
+	m.EXPECT().GetTargetGroupPublishers(gomock.Any(), []string{"arn:tg"}).Return(map[string][]api.PortPublisher{
+		"arn:tg": {{URL: "lb-123.eu-west-3.elb.amazonaws.com:80", TargetPort: 80, PublishedPort: 80, Protocol: "http"}},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	host, port, err := backend.Port(context.TODO(), t.Name(), "foo", 80, api.PortOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, host, "lb-123.eu-west-3.elb.amazonaws.com")
+	assert.Equal(t, port, 80)
+}
+
+func TestPortFromBlueGreenLoadBalancer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().GetStackClusterID(gomock.Any(), t.Name()).Return("cluster", nil)
+	m.EXPECT().ListStackResources(gomock.Any(), t.Name()).Return(stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:foo"},
+	}, nil)
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{
+			ServiceArn:           aws.String("arn:foo"),
+			DeploymentController: &ecs.DeploymentController{Type: aws.String(ecs.DeploymentControllerTypeExternal)},
+			TaskSets: []*ecs.TaskSet{
+				{Status: aws.String("ACTIVE"), LoadBalancers: []*ecs.LoadBalancer{{TargetGroupArn: aws.String("arn:tg/green")}}},
+				{Status: aws.String("PRIMARY"), LoadBalancers: []*ecs.LoadBalancer{{TargetGroupArn: aws.String("arn:tg/blue")}}},
+			},
+		},
+	}, nil)
+	m.EXPECT().GetTargetGroupPublishers(gomock.Any(), []string{"arn:tg/blue"}).Return(map[string][]api.PortPublisher{
+		"arn:tg/blue": {{URL: "lb-123.eu-west-3.elb.amazonaws.com:80", TargetPort: 80, PublishedPort: 80, Protocol: "http"}},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	host, port, err := backend.Port(context.TODO(), t.Name(), "foo", 80, api.PortOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, host, "lb-123.eu-west-3.elb.amazonaws.com")
+	assert.Equal(t, port, 80)
+}
+
+func TestPortFromTaskPublicIP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().GetStackClusterID(gomock.Any(), t.Name()).Return("cluster", nil)
+	m.EXPECT().ListStackResources(gomock.Any(), t.Name()).Return(stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:foo"},
+	}, nil)
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{ServiceArn: aws.String("arn:foo")},
+	}, nil)
+	m.EXPECT().GetTargetGroupPublishers(gomock.Any(), nil).Return(nil, nil)
+	m.EXPECT().DescribeServiceTasks(gomock.Any(), "cluster", t.Name(), "foo").Return([]api.ContainerSummary{
+		{ID: testTaskArn, State: "Running"},
+	}, nil)
+	m.EXPECT().DescribeTask(gomock.Any(), "cluster", testTaskArn).Return(&ecs.Task{
+		TaskDefinitionArn: aws.String(testTaskDefinition),
+		Attachments: []*ecs.Attachment{
+			{
+				Type: aws.String("ElasticNetworkInterface"),
+				Details: []*ecs.KeyValuePair{
+					{Name: aws.String("subnetId"), Value: aws.String("subnet-123")},
+					{Name: aws.String("networkInterfaceId"), Value: aws.String("eni-123")},
+				},
+			},
+		},
+	}, nil)
+	m.EXPECT().DescribeTaskDefinition(gomock.Any(), testTaskDefinition).Return(&ecs.TaskDefinition{
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{
+				Name: aws.String("foo"),
+				PortMappings: []*ecs.PortMapping{
+					{ContainerPort: aws.Int64(8080), Protocol: aws.String("tcp")},
+				},
+			},
+		},
+	}, nil, nil)
+	m.EXPECT().GetPublicIPs(gomock.Any(), "eni-123").Return(map[string]string{"eni-123": "15.236.1.2"}, nil)
+
+	backend := &ComposeECS{aws: m}
+	host, port, err := backend.Port(context.TODO(), t.Name(), "foo", 8080, api.PortOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, host, "15.236.1.2")
+	assert.Equal(t, port, 8080)
+}
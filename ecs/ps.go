@@ -18,17 +18,33 @@ package ecs
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/go-units"
+	"golang.org/x/sync/errgroup"
+)
 
-	"github.com/docker/compose-ecs/utils"
+const (
+	// revisionLabel is the task definition revision a task runs, set on tasks listed by ps
+	revisionLabel = "com.docker.compose.ecs.revision"
+	// privateIPLabel is the task private IPv4 address, set on tasks listed by ps
+	privateIPLabel = "com.docker.compose.ecs.private_ip"
+	// availabilityZoneLabel is the availability zone a task runs in, set on tasks listed by ps
+	availabilityZoneLabel = "com.docker.compose.ecs.availability_zone"
+	// stoppedReasonLabel is the reason a task stopped, set on tasks listed by ps
+	stoppedReasonLabel = "com.docker.compose.ecs.stopped_reason"
 )
 
-func (b *ComposeECS) Ps(ctx context.Context, projectName string, options api.PsOptions) ([]api.ContainerSummary, error) {
-	if err := checkUnsupportedPsOptions(ctx, options); err != nil {
-		return nil, err
-	}
+// psConcurrency is the maximum number of services which tasks are described concurrently
+const psConcurrency = 5
 
+func (b *ComposeECS) Ps(ctx context.Context, projectName string, options api.PsOptions) ([]api.ContainerSummary, error) {
 	cluster, err := b.aws.GetStackClusterID(ctx, projectName)
 	if err != nil {
 		return nil, err
@@ -42,27 +58,181 @@ func (b *ComposeECS) Ps(ctx context.Context, projectName string, options api.PsO
 		return nil, nil
 	}
 
+	services, err := b.aws.DescribeServices(ctx, cluster, servicesARN)
+	if err != nil {
+		return nil, err
+	}
+	var targetGroups []string
+	for _, service := range services {
+		loadBalancers, _ := serviceNetwork(service)
+		for _, lb := range loadBalancers {
+			targetGroups = append(targetGroups, aws.StringValue(lb.TargetGroupArn))
+		}
+	}
+	publishers, err := b.aws.GetTargetGroupPublishers(ctx, targetGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	// tasks are collected by service index, so output order doesn't depend on which request completes first
+	tasks := make([][]api.ContainerSummary, len(services))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(psConcurrency)
+	for i, service := range services {
+		i, service := i, service
+		eg.Go(func() error {
+			var err error
+			tasks[i], err = b.serviceTasks(ctx, cluster, projectName, service, options.All)
+			if err != nil {
+				return err
+			}
+			var servicePublishers []api.PortPublisher
+			loadBalancers, _ := serviceNetwork(service)
+			for _, lb := range loadBalancers {
+				servicePublishers = append(servicePublishers, publishers[aws.StringValue(lb.TargetGroupArn)]...)
+			}
+			for j := range tasks[i] {
+				tasks[i][j].Publishers = servicePublishers
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
 	summary := []api.ContainerSummary{}
-	for _, arn := range servicesARN {
-		service, err := b.aws.DescribeService(ctx, cluster, arn)
+	for _, t := range tasks {
+		summary = append(summary, t...)
+	}
+	return summary, nil
+}
+
+// serviceTasks lists running tasks of service, and stopped ones if all is set
+func (b *ComposeECS) serviceTasks(ctx context.Context, cluster string, project string, service *ecs.Service, all bool) ([]api.ContainerSummary, error) {
+	tasks, err := b.aws.DescribeServiceTasks(ctx, cluster, project, serviceName(service))
+	if err != nil {
+		return nil, err
+	}
+	if !all {
+		return tasks, nil
+	}
+	stopped, err := b.aws.GetServiceTasks(ctx, cluster, aws.StringValue(service.ServiceArn), true)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range stopped {
+		task, err := taskSummary(t, time.Now())
 		if err != nil {
 			return nil, err
 		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
 
-		tasks, err := b.aws.DescribeServiceTasks(ctx, cluster, projectName, service.Name)
-		if err != nil {
-			return nil, err
+// taskSummary describes an ECS task as a container, with the exit code of the service container once stopped
+func taskSummary(t *ecs.Task, now time.Time) (api.ContainerSummary, error) {
+	id, err := arn.Parse(aws.StringValue(t.TaskArn))
+	if err != nil {
+		return api.ContainerSummary{}, err
+	}
+	labels := map[string]string{}
+	for _, tag := range t.Tags {
+		switch key := aws.StringValue(tag.Key); key {
+		case api.ProjectLabel, api.ServiceLabel, api.OneoffLabel:
+			labels[key] = aws.StringValue(tag.Value)
 		}
+	}
+	if definition := aws.StringValue(t.TaskDefinitionArn); definition != "" {
+		labels[revisionLabel] = definition[strings.LastIndex(definition, ":")+1:]
+	}
+	if ip := taskPrivateIP(t); ip != "" {
+		labels[privateIPLabel] = ip
+	}
+	if az := aws.StringValue(t.AvailabilityZone); az != "" {
+		labels[availabilityZoneLabel] = az
+	}
+
+	summary := api.ContainerSummary{
+		ID:      id.String(),
+		Name:    id.Resource,
+		Project: labels[api.ProjectLabel],
+		Service: labels[api.ServiceLabel],
+		//nolint:staticcheck // Preserving for compatibility
+		State:  strings.Title(strings.ToLower(aws.StringValue(t.LastStatus))),
+		Labels: labels,
+	}
+	if t.CreatedAt != nil {
+		summary.Created = t.CreatedAt.Unix()
+	}
+	if health := aws.StringValue(t.HealthStatus); health != ecs.HealthStatusUnknown {
+		summary.Health = strings.ToLower(health)
+	}
+	container := serviceContainer(t, summary.Service)
+	if container != nil {
+		summary.Image = aws.StringValue(container.Image)
+	}
 
-		for i, t := range tasks {
-			t.Publishers = service.Publishers
-			tasks[i] = t
+	switch {
+	case aws.StringValue(t.LastStatus) == ecs.DesiredStatusStopped:
+		reason := aws.StringValue(t.StoppedReason)
+		if container != nil && aws.StringValue(container.Reason) != "" {
+			reason = fmt.Sprintf("%s: %s", reason, aws.StringValue(container.Reason))
 		}
-		summary = append(summary, tasks...)
+		if reason != "" {
+			labels[stoppedReasonLabel] = reason
+		}
+		status := "Stopped"
+		if container != nil && container.ExitCode != nil {
+			summary.ExitCode = int(aws.Int64Value(container.ExitCode))
+			status = fmt.Sprintf("Exited (%d)", summary.ExitCode)
+		}
+		if t.StoppedAt != nil {
+			status = fmt.Sprintf("%s %s ago", status, units.HumanDuration(now.Sub(aws.TimeValue(t.StoppedAt))))
+		}
+		summary.Status = status
+		if reason != "" {
+			summary.Status = fmt.Sprintf("%s: %s", status, reason)
+		}
+	case t.StartedAt != nil:
+		summary.Status = "Up " + units.HumanDuration(now.Sub(aws.TimeValue(t.StartedAt)))
+		if summary.Health != "" {
+			summary.Status = fmt.Sprintf("%s (%s)", summary.Status, summary.Health)
+		}
+	default:
+		summary.Status = summary.State
 	}
 	return summary, nil
 }
 
-func checkUnsupportedPsOptions(ctx context.Context, o api.PsOptions) error {
-	return utils.CheckUnsupported(ctx, nil, o.All, false, "ps", "all")
+// serviceContainer returns the container running the compose service in task, rather than a sidecar
+func serviceContainer(t *ecs.Task, service string) *ecs.Container {
+	for _, c := range t.Containers {
+		if aws.StringValue(c.Name) == service {
+			return c
+		}
+	}
+	if len(t.Containers) > 0 {
+		return t.Containers[0]
+	}
+	return nil
+}
+
+func taskPrivateIP(t *ecs.Task) string {
+	for _, c := range t.Containers {
+		for _, ni := range c.NetworkInterfaces {
+			if ip := aws.StringValue(ni.PrivateIpv4Address); ip != "" {
+				return ip
+			}
+		}
+	}
+	for _, attachment := range t.Attachments {
+		for _, detail := range attachment.Details {
+			if aws.StringValue(detail.Name) == "privateIPv4Address" {
+				return aws.StringValue(detail.Value)
+			}
+		}
+	}
+	return ""
 }
@@ -0,0 +1,185 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestPsAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+
+	m.EXPECT().GetStackClusterID(gomock.Any(), t.Name()).Return("cluster", nil)
+	m.EXPECT().ListStackServices(gomock.Any(), t.Name()).Return([]string{"arn:foo"}, nil)
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{ServiceArn: aws.String("arn:foo"), Tags: []*ecs.Tag{{Key: aws.String(api.ServiceLabel), Value: aws.String("foo")}}},
+	}, nil)
+	m.EXPECT().GetTargetGroupPublishers(gomock.Any(), nil).Return(nil, nil)
+	m.EXPECT().DescribeServiceTasks(gomock.Any(), "cluster", t.Name(), "foo").Return([]api.ContainerSummary{
+		{ID: "running", Service: "foo", State: "Running"},
+	}, nil)
+	m.EXPECT().GetServiceTasks(gomock.Any(), "cluster", "arn:foo", true).Return([]*ecs.Task{
+		{
+			TaskArn:           aws.String(testTaskArn),
+			TaskDefinitionArn: aws.String(testTaskDefinition),
+			LastStatus:        aws.String(ecs.DesiredStatusStopped),
+			StoppedReason:     aws.String("Essential container in task exited"),
+		},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	containers, err := backend.Ps(context.TODO(), t.Name(), api.PsOptions{All: true})
+	assert.NilError(t, err)
+	assert.Equal(t, len(containers), 2)
+	assert.Equal(t, containers[0].ID, "running")
+	assert.Equal(t, containers[1].ID, testTaskArn)
+	assert.Equal(t, containers[1].State, "Stopped")
+}
+
+func TestPsOrderAndPublishers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+
+	arns := []string{"arn:foo", "arn:bar", "arn:zot"}
+	m.EXPECT().GetStackClusterID(gomock.Any(), t.Name()).Return("cluster", nil)
+	m.EXPECT().ListStackServices(gomock.Any(), t.Name()).Return(arns, nil)
+	var services []*ecs.Service
+	for _, arn := range arns {
+		name := arn[4:]
+		services = append(services, &ecs.Service{
+			ServiceArn:    aws.String(arn),
+			Tags:          []*ecs.Tag{{Key: aws.String(api.ServiceLabel), Value: aws.String(name)}},
+			LoadBalancers: []*ecs.LoadBalancer{{TargetGroupArn: aws.String("arn:tg/" + name)}},
+		})
+		m.EXPECT().DescribeServiceTasks(gomock.Any(), "cluster", t.Name(), name).Return([]api.ContainerSummary{
+			{ID: name + "-1", Service: name}, {ID: name + "-2", Service: name},
+		}, nil)
+	}
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", arns).Return(services, nil)
+	m.EXPECT().GetTargetGroupPublishers(gomock.Any(), []string{"arn:tg/foo", "arn:tg/bar", "arn:tg/zot"}).Return(map[string][]api.PortPublisher{
+		"arn:tg/foo": {{URL: "lb:80", TargetPort: 80, PublishedPort: 80, Protocol: "tcp"}},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	containers, err := backend.Ps(context.TODO(), t.Name(), api.PsOptions{})
+	assert.NilError(t, err)
+	var ids []string
+	for _, c := range containers {
+		ids = append(ids, c.ID)
+	}
+	assert.DeepEqual(t, ids, []string{"foo-1", "foo-2", "bar-1", "bar-2", "zot-1", "zot-2"})
+	assert.Equal(t, containers[0].Publishers[0].URL, "lb:80")
+	assert.Equal(t, len(containers[2].Publishers), 0)
+}
+
+func TestPsBlueGreenPublishers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+
+	m.EXPECT().GetStackClusterID(gomock.Any(), t.Name()).Return("cluster", nil)
+	m.EXPECT().ListStackServices(gomock.Any(), t.Name()).Return([]string{"arn:foo"}, nil)
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{
+			ServiceArn:           aws.String("arn:foo"),
+			Tags:                 []*ecs.Tag{{Key: aws.String(api.ServiceLabel), Value: aws.String("foo")}},
+			DeploymentController: &ecs.DeploymentController{Type: aws.String(ecs.DeploymentControllerTypeExternal)},
+			TaskSets: []*ecs.TaskSet{
+				{Status: aws.String("PRIMARY"), LoadBalancers: []*ecs.LoadBalancer{{TargetGroupArn: aws.String("arn:tg/blue")}}},
+				{Status: aws.String("ACTIVE"), LoadBalancers: []*ecs.LoadBalancer{{TargetGroupArn: aws.String("arn:tg/green")}}},
+			},
+		},
+	}, nil)
+	m.EXPECT().GetTargetGroupPublishers(gomock.Any(), []string{"arn:tg/blue"}).Return(map[string][]api.PortPublisher{
+		"arn:tg/blue": {{URL: "lb:80", TargetPort: 80, PublishedPort: 80, Protocol: "tcp"}},
+	}, nil)
+	m.EXPECT().DescribeServiceTasks(gomock.Any(), "cluster", t.Name(), "foo").Return([]api.ContainerSummary{
+		{ID: "foo-1", Service: "foo"},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	containers, err := backend.Ps(context.TODO(), t.Name(), api.PsOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(containers), 1)
+	assert.DeepEqual(t, containers[0].Publishers, []api.PortPublisher{{URL: "lb:80", TargetPort: 80, PublishedPort: 80, Protocol: "tcp"}})
+}
+
+func TestStoppedTaskSummary(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	summary, err := taskSummary(&ecs.Task{
+		TaskArn:           aws.String(testTaskArn),
+		TaskDefinitionArn: aws.String(testTaskDefinition),
+		LastStatus:        aws.String(ecs.DesiredStatusStopped),
+		StoppedReason:     aws.String("Essential container in task exited"),
+		StoppedAt:         aws.Time(now.Add(-5 * time.Minute)),
+		AvailabilityZone:  aws.String("eu-west-3a"),
+		HealthStatus:      aws.String(ecs.HealthStatusUnhealthy),
+		Tags: []*ecs.Tag{
+			{Key: aws.String(api.ProjectLabel), Value: aws.String("test")},
+			{Key: aws.String(api.ServiceLabel), Value: aws.String("foo")},
+		},
+		Containers: []*ecs.Container{
+			{Name: aws.String("foo_ResolvConf_InitContainer"), ExitCode: aws.Int64(0)},
+			{
+				Name:     aws.String("foo"),
+				Image:    aws.String("nginx"),
+				ExitCode: aws.Int64(137),
+				NetworkInterfaces: []*ecs.NetworkInterface{
+					{PrivateIpv4Address: aws.String("10.0.1.23")},
+				},
+			},
+		},
+	}, now)
+	assert.NilError(t, err)
+	assert.Equal(t, summary.Service, "foo")
+	assert.Equal(t, summary.Image, "nginx")
+	assert.Equal(t, summary.ExitCode, 137)
+	assert.Equal(t, summary.Health, "unhealthy")
+	assert.Equal(t, summary.Status, "Exited (137) 5 minutes ago: Essential container in task exited")
+	assert.DeepEqual(t, summary.Labels, map[string]string{
+		api.ProjectLabel:      "test",
+		api.ServiceLabel:      "foo",
+		revisionLabel:         "1",
+		privateIPLabel:        "10.0.1.23",
+		availabilityZoneLabel: "eu-west-3a",
+		stoppedReasonLabel:    "Essential container in task exited",
+	})
+}
+
+func TestRunningTaskSummary(t *testing.T) {
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	summary, err := taskSummary(&ecs.Task{
+		TaskArn:      aws.String(testTaskArn),
+		LastStatus:   aws.String("RUNNING"),
+		StartedAt:    aws.Time(now.Add(-2 * time.Hour)),
+		HealthStatus: aws.String(ecs.HealthStatusHealthy),
+	}, now)
+	assert.NilError(t, err)
+	assert.Equal(t, summary.State, "Running")
+	assert.Equal(t, summary.Status, "Up 2 hours (healthy)")
+}
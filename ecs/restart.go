@@ -0,0 +1,104 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+)
+
+// Restart forces a new deployment for services, so tasks are replaced and pick up the latest secrets and image tags.
+// Timeout applies to the whole rollout. Blue/green services can't be restarted, as their deployments are run by CodeDeploy.
+func (b *ComposeECS) Restart(ctx context.Context, project *types.Project, options api.RestartOptions) error {
+	return progress.Run(ctx, func(ctx context.Context) error {
+		return b.restart(ctx, project.Name, options)
+	})
+}
+
+func (b *ComposeECS) restart(ctx context.Context, project string, options api.RestartOptions) error {
+	cluster, err := b.aws.GetStackClusterID(ctx, project)
+	if err != nil {
+		return err
+	}
+	resources, err := b.aws.ListStackResources(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	selected := selectServices(resources, options.Services)
+	if err := b.checkRestartable(ctx, cluster, selected); err != nil {
+		return err
+	}
+
+	w := progress.ContextWriter(ctx)
+	var restarted stackResources
+	err = selected.apply(awsTypeService, func(r stackResource) error {
+		w.Event(progress.RestartingEvent(r.LogicalID))
+		if err := b.aws.ForceNewDeployment(ctx, cluster, r.ARN); err != nil {
+			return err
+		}
+		restarted = append(restarted, r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if options.Timeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *options.Timeout)
+		defer cancel()
+	}
+	return b.waitServicesRollout(ctx, cluster, restarted, progress.RestartedEvent)
+}
+
+// checkRestartable fails for blue/green services, as forcing a new deployment isn't supported by the EXTERNAL deployment controller
+func (b *ComposeECS) checkRestartable(ctx context.Context, cluster string, resources stackResources) error {
+	ids := map[string]string{}
+	var arns []string
+	for _, r := range resources {
+		if r.Type == awsTypeService {
+			ids[r.ARN] = r.LogicalID
+			arns = append(arns, r.ARN)
+		}
+	}
+	if len(arns) == 0 {
+		return nil
+	}
+	services, err := b.aws.DescribeServices(ctx, cluster, arns)
+	if err != nil {
+		return err
+	}
+	var blueGreen []string
+	for _, s := range services {
+		if isBlueGreenService(s) {
+			blueGreen = append(blueGreen, ids[aws.StringValue(s.ServiceArn)])
+		}
+	}
+	if len(blueGreen) == 0 {
+		return nil
+	}
+	sort.Strings(blueGreen)
+	return fmt.Errorf("%s can't be restarted as deployed blue/green by CodeDeploy, run `up` to start a new deployment", strings.Join(blueGreen, ", "))
+}
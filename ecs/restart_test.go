@@ -0,0 +1,182 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func TestRestartService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectStackServices(m.EXPECT(), t.Name())
+
+	m.EXPECT().ForceNewDeployment(gomock.Any(), "cluster", "arn:foo").Return(nil)
+	// services are described to check they're not blue/green, then to wait for rollout
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{
+			ServiceArn: aws.String("arn:foo"),
+			Deployments: []*ecs.Deployment{
+				{Status: aws.String("PRIMARY"), RolloutState: aws.String(ecs.DeploymentRolloutStateCompleted), DesiredCount: aws.Int64(1), RunningCount: aws.Int64(1)},
+			},
+		},
+	}, nil).Times(2)
+
+	backend := &ComposeECS{aws: m}
+	timeout := time.Minute
+	err := backend.restart(context.TODO(), t.Name(), api.RestartOptions{
+		Services: []string{"foo"},
+		Timeout:  &timeout,
+	})
+	assert.NilError(t, err)
+}
+
+func TestRestartBlueGreenService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectStackServices(m.EXPECT(), t.Name())
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{
+			ServiceArn:           aws.String("arn:foo"),
+			DeploymentController: &ecs.DeploymentController{Type: aws.String(ecs.DeploymentControllerTypeExternal)},
+		},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	err := backend.restart(context.TODO(), t.Name(), api.RestartOptions{
+		Services: []string{"foo"},
+	})
+	assert.Error(t, err, "FooService can't be restarted as deployed blue/green by CodeDeploy, run `up` to start a new deployment")
+}
+
+func TestRolloutCompleted(t *testing.T) {
+	primary := &ecs.Deployment{Id: aws.String("ecs-svc/2"), Status: aws.String("PRIMARY"), DesiredCount: aws.Int64(2), RunningCount: aws.Int64(1), PendingCount: aws.Int64(1)}
+	active := &ecs.Deployment{Id: aws.String("ecs-svc/1"), Status: aws.String("ACTIVE"), DesiredCount: aws.Int64(2), RunningCount: aws.Int64(2)}
+	service := &ecs.Service{Deployments: []*ecs.Deployment{active, primary}}
+
+	completed, err := rolloutCompleted(service)
+	assert.NilError(t, err)
+	assert.Check(t, !completed)
+	assert.Equal(t, formatDeployments(service.Deployments), "PRIMARY 1/2 running, 1 pending, ACTIVE 2/2 running, 0 pending")
+
+	primary.RolloutState = aws.String(ecs.DeploymentRolloutStateFailed)
+	primary.RolloutStateReason = aws.String("ECS deployment circuit breaker: tasks failed to start.")
+	_, err = rolloutCompleted(service)
+	assert.ErrorContains(t, err, "circuit breaker")
+
+	primary.RolloutState = nil
+	primary.RunningCount = aws.Int64(2)
+	service.Deployments = []*ecs.Deployment{primary}
+	completed, err = rolloutCompleted(service)
+	assert.NilError(t, err)
+	assert.Check(t, completed)
+}
+
+func TestRolloutCompletedBlueGreen(t *testing.T) {
+	green := &ecs.TaskSet{Status: aws.String("PRIMARY"), StabilityStatus: aws.String(ecs.StabilityStatusStabilizing), ComputedDesiredCount: aws.Int64(2), RunningCount: aws.Int64(1), PendingCount: aws.Int64(1)}
+	blue := &ecs.TaskSet{Status: aws.String("ACTIVE"), StabilityStatus: aws.String(ecs.StabilityStatusSteadyState), ComputedDesiredCount: aws.Int64(2), RunningCount: aws.Int64(2)}
+	service := &ecs.Service{
+		DeploymentController: &ecs.DeploymentController{Type: aws.String(ecs.DeploymentControllerTypeExternal)},
+		TaskSets:             []*ecs.TaskSet{blue, green},
+	}
+
+	completed, err := rolloutCompleted(service)
+	assert.NilError(t, err)
+	assert.Check(t, !completed)
+	assert.Equal(t, formatRollout(service), "PRIMARY 1/2 running, 1 pending, ACTIVE 2/2 running, 0 pending")
+
+	green.StabilityStatus = aws.String(ecs.StabilityStatusSteadyState)
+	green.RunningCount = aws.Int64(2)
+	service.TaskSets = []*ecs.TaskSet{green}
+	completed, err = rolloutCompleted(service)
+	assert.NilError(t, err)
+	assert.Check(t, completed)
+}
+
+func TestWaitProjectServicesHealthyTargets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectStackServices(m.EXPECT(), t.Name())
+
+	service := func(arn string, lbs ...*ecs.LoadBalancer) *ecs.Service {
+		return &ecs.Service{
+			ServiceArn:    aws.String(arn),
+			DesiredCount:  aws.Int64(1),
+			RunningCount:  aws.Int64(1),
+			LoadBalancers: lbs,
+			Deployments: []*ecs.Deployment{
+				{Status: aws.String("PRIMARY"), RolloutState: aws.String(ecs.DeploymentRolloutStateCompleted), DesiredCount: aws.Int64(1), RunningCount: aws.Int64(1)},
+			},
+		}
+	}
+	target := func(state string) []*elbv2.TargetHealthDescription {
+		return []*elbv2.TargetHealthDescription{
+			{TargetHealth: &elbv2.TargetHealth{State: aws.String(state), Description: aws.String("Health checks failed")}},
+		}
+	}
+	gomock.InOrder(
+		m.EXPECT().DescribeServices(gomock.Any(), "cluster", gomock.Len(2)).Return([]*ecs.Service{
+			service("arn:foo", &ecs.LoadBalancer{TargetGroupArn: aws.String("arn:tg")}),
+			service("arn:bar"),
+		}, nil),
+		m.EXPECT().DescribeTargetHealth(gomock.Any(), "arn:tg").Return(target(elbv2.TargetHealthStateEnumUnhealthy), nil),
+		m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+			service("arn:foo", &ecs.LoadBalancer{TargetGroupArn: aws.String("arn:tg")}),
+		}, nil),
+		m.EXPECT().DescribeTargetHealth(gomock.Any(), "arn:tg").Return(target(elbv2.TargetHealthStateEnumHealthy), nil),
+	)
+
+	backend := &ComposeECS{aws: m}
+	err := backend.waitProjectServices(context.TODO(), t.Name())
+	assert.NilError(t, err)
+}
+
+func TestWaitServicesRolloutFailureReportsEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	m.EXPECT().DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{
+			ServiceArn: aws.String("arn:foo"),
+			Deployments: []*ecs.Deployment{
+				{Id: aws.String("ecs-svc/2"), Status: aws.String("PRIMARY"), RolloutState: aws.String(ecs.DeploymentRolloutStateFailed), RolloutStateReason: aws.String("tasks failed to start")},
+			},
+			Events: []*ecs.ServiceEvent{
+				{CreatedAt: aws.Time(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)), Message: aws.String("(service foo) deployment failed: tasks failed to start.")},
+			},
+		},
+	}, nil)
+
+	backend := &ComposeECS{aws: m}
+	err := backend.waitServicesRollout(context.TODO(), "cluster", stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:foo"},
+	}, progress.RestartedEvent)
+	assert.Error(t, err, "FooService: deployment ecs-svc/2 failed: tasks failed to start\n  2023-01-02T03:04:05Z (service foo) deployment failed: tasks failed to start.")
+}
@@ -0,0 +1,237 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/docker/compose/v2/pkg/progress"
+)
+
+const (
+	rolloutPollInterval = time.Second
+	// rolloutEventsCount is the number of recent service events reported when rollout fails
+	rolloutEventsCount = 5
+)
+
+// waitServicesRollout reports deployments progress for services until each has a single, completed, PRIMARY deployment (or task set
+// for blue/green services), and healthy targets in its load balancers target groups. done is sent as event for services once rollout has completed.
+func (b *ComposeECS) waitServicesRollout(ctx context.Context, cluster string, services stackResources, done func(id string) progress.Event) error {
+	w := progress.ContextWriter(ctx)
+	pending := map[string]string{}
+	for _, r := range services {
+		pending[r.ARN] = r.LogicalID
+	}
+	described := map[string]*ecs.Service{}
+
+	ticker := time.NewTicker(rolloutPollInterval)
+	defer ticker.Stop()
+	for len(pending) > 0 {
+		var arns []string
+		for arn := range pending {
+			arns = append(arns, arn)
+		}
+		services, err := b.aws.DescribeServices(ctx, cluster, arns)
+		if err != nil {
+			return rolloutError(ctx, err, pending, described)
+		}
+		for _, service := range services {
+			arn := aws.StringValue(service.ServiceArn)
+			id := pending[arn]
+			described[arn] = service
+			completed, err := rolloutCompleted(service)
+			if err != nil {
+				w.Event(progress.ErrorMessageEvent(id, err.Error()))
+				return fmt.Errorf("%s: %w%s", id, err, formatServiceEvents(service.Events))
+			}
+			if !completed {
+				w.Event(progress.NewEvent(id, progress.Working, formatRollout(service)))
+				continue
+			}
+			unhealthy, err := b.unhealthyTargets(ctx, service)
+			if err != nil {
+				return rolloutError(ctx, err, pending, described)
+			}
+			if unhealthy != "" {
+				w.Event(progress.NewEvent(id, progress.Working, unhealthy))
+				continue
+			}
+			w.Event(done(id))
+			delete(pending, arn)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return rolloutError(ctx, ctx.Err(), pending, described)
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// rolloutError names services which rollout didn't complete on timeout, with their recent events
+func rolloutError(ctx context.Context, err error, pending map[string]string, described map[string]*ecs.Service) error {
+	if ctx.Err() != context.DeadlineExceeded {
+		return err
+	}
+	var ids []string
+	var events string
+	for arn, id := range pending {
+		ids = append(ids, id)
+		if service, ok := described[arn]; ok {
+			events += formatServiceEvents(service.Events)
+		}
+	}
+	sort.Strings(ids)
+	return fmt.Errorf("timeout waiting for %s rollout to complete%s", strings.Join(ids, ", "), events)
+}
+
+// unhealthyTargets describes the target groups of service which don't have a healthy target per running task, if any
+func (b *ComposeECS) unhealthyTargets(ctx context.Context, service *ecs.Service) (string, error) {
+	running := int(aws.Int64Value(service.RunningCount))
+	if running == 0 {
+		return "", nil
+	}
+	var unhealthy []string
+	loadBalancers, _ := serviceNetwork(service)
+	for _, lb := range loadBalancers {
+		if lb.TargetGroupArn == nil {
+			continue
+		}
+		targets, err := b.aws.DescribeTargetHealth(ctx, aws.StringValue(lb.TargetGroupArn))
+		if err != nil {
+			return "", err
+		}
+		var healthy int
+		var reasons []string
+		for _, t := range targets {
+			if t.TargetHealth == nil {
+				continue
+			}
+			if aws.StringValue(t.TargetHealth.State) == elbv2.TargetHealthStateEnumHealthy {
+				healthy++
+			} else if reason := aws.StringValue(t.TargetHealth.Description); reason != "" {
+				reasons = append(reasons, reason)
+			}
+		}
+		if healthy < running {
+			text := fmt.Sprintf("%d/%d healthy targets", healthy, running)
+			if len(reasons) > 0 {
+				text = fmt.Sprintf("%s (%s)", text, reasons[0])
+			}
+			unhealthy = append(unhealthy, text)
+		}
+	}
+	return strings.Join(unhealthy, ", "), nil
+}
+
+// formatServiceEvents lists most recent service events, as ECS reports them newest first
+func formatServiceEvents(events []*ecs.ServiceEvent) string {
+	if len(events) > rolloutEventsCount {
+		events = events[:rolloutEventsCount]
+	}
+	var text string
+	for _, e := range events {
+		text += fmt.Sprintf("\n  %s %s", aws.TimeValue(e.CreatedAt).Format(time.RFC3339), aws.StringValue(e.Message))
+	}
+	return text
+}
+
+// rolloutCompleted tells if the PRIMARY deployment has replaced all others and reached desired count
+func rolloutCompleted(service *ecs.Service) (bool, error) {
+	if isBlueGreenService(service) {
+		return taskSetsCompleted(service), nil
+	}
+	for _, d := range service.Deployments {
+		if aws.StringValue(d.Status) != "PRIMARY" {
+			continue
+		}
+		switch aws.StringValue(d.RolloutState) {
+		case ecs.DeploymentRolloutStateFailed:
+			return false, fmt.Errorf("deployment %s failed: %s", aws.StringValue(d.Id), aws.StringValue(d.RolloutStateReason))
+		case ecs.DeploymentRolloutStateInProgress:
+			return false, nil
+		}
+		return len(service.Deployments) == 1 && aws.Int64Value(d.RunningCount) == aws.Int64Value(d.DesiredCount) &&
+			aws.Int64Value(service.RunningCount) == aws.Int64Value(service.DesiredCount), nil
+	}
+	return false, nil
+}
+
+// isBlueGreenService tells if service is deployed by CodeDeploy, through task sets rather than ECS deployments
+func isBlueGreenService(service *ecs.Service) bool {
+	return service.DeploymentController != nil &&
+		aws.StringValue(service.DeploymentController.Type) == ecs.DeploymentControllerTypeExternal
+}
+
+// taskSetsCompleted tells if the PRIMARY task set has replaced all others and reached steady state. Failed deployments are rolled back
+// by CodeDeploy, which makes the previous task set PRIMARY again
+func taskSetsCompleted(service *ecs.Service) bool {
+	if len(service.TaskSets) != 1 {
+		return false
+	}
+	t := service.TaskSets[0]
+	return aws.StringValue(t.Status) == "PRIMARY" && aws.StringValue(t.StabilityStatus) == ecs.StabilityStatusSteadyState &&
+		aws.Int64Value(t.RunningCount) == aws.Int64Value(t.ComputedDesiredCount)
+}
+
+func formatRollout(service *ecs.Service) string {
+	if isBlueGreenService(service) {
+		return formatTaskSets(service.TaskSets)
+	}
+	return formatDeployments(service.Deployments)
+}
+
+// formatTaskSets describes running and pending tasks for task sets, PRIMARY first
+func formatTaskSets(taskSets []*ecs.TaskSet) string {
+	var primary, others []string
+	for _, t := range taskSets {
+		status := aws.StringValue(t.Status)
+		text := fmt.Sprintf("%s %d/%d running, %d pending", status, aws.Int64Value(t.RunningCount), aws.Int64Value(t.ComputedDesiredCount), aws.Int64Value(t.PendingCount))
+		if status == "PRIMARY" {
+			primary = append(primary, text)
+		} else {
+			others = append(others, text)
+		}
+	}
+	return strings.Join(append(primary, others...), ", ")
+}
+
+// formatDeployments describes running and pending tasks for deployments, PRIMARY first
+func formatDeployments(deployments []*ecs.Deployment) string {
+	var primary, others []string
+	for _, d := range deployments {
+		status := aws.StringValue(d.Status)
+		text := fmt.Sprintf("%s %d/%d running, %d pending", status, aws.Int64Value(d.RunningCount), aws.Int64Value(d.DesiredCount), aws.Int64Value(d.PendingCount))
+		if status == "PRIMARY" {
+			primary = append(primary, text)
+		} else {
+			others = append(others, text)
+		}
+	}
+	return strings.Join(append(primary, others...), ", ")
+}
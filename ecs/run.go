@@ -0,0 +1,345 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-ecs/utils"
+)
+
+const (
+	runTaskStartedBy    = "compose-ecs run"
+	runTaskPollInterval = time.Second
+)
+
+// Create is only used by `run` to create dependencies for the one-off task. On ECS those are deployed as part of the
+// application stack by `up`, so we just check the stack exists.
+func (b *ComposeECS) Create(ctx context.Context, project *types.Project, opts api.CreateOptions) error {
+	exists, err := b.aws.StackExists(ctx, project.Name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Wrapf(api.ErrNotFound, "project %q is not deployed, run `compose-ecs up` first", project.Name)
+	}
+	return nil
+}
+
+func (b *ComposeECS) RunOneOffContainer(ctx context.Context, project *types.Project, opts api.RunOptions) (int, error) {
+	if err := checkUnsupportedRunOptions(ctx, opts); err != nil {
+		return 0, err
+	}
+	if _, err := project.GetService(opts.Service); err != nil {
+		return 0, err
+	}
+
+	cluster, err := b.aws.GetStackClusterID(ctx, project.Name)
+	if err != nil {
+		return 0, err
+	}
+	serviceArn, err := b.getServiceArn(ctx, project.Name, opts.Service)
+	if err != nil {
+		return 0, err
+	}
+	definitions, err := b.aws.GetServiceTaskDefinition(ctx, cluster, []string{serviceArn})
+	if err != nil {
+		return 0, err
+	}
+	taskDefinition := definitions[serviceArn]
+	definition, tags, err := b.aws.DescribeTaskDefinition(ctx, taskDefinition)
+	if err != nil {
+		return 0, err
+	}
+	container, err := getContainerDefinition(definition, opts.Service)
+	if err != nil {
+		return 0, err
+	}
+
+	if opts.User != "" || opts.WorkingDir != "" || len(opts.Entrypoint) > 0 {
+		// those can't be set by a container override, so we need a dedicated task definition revision
+		if opts.User != "" {
+			container.User = aws.String(opts.User)
+		}
+		if opts.WorkingDir != "" {
+			container.WorkingDirectory = aws.String(opts.WorkingDir)
+		}
+		if len(opts.Entrypoint) > 0 {
+			container.EntryPoint = aws.StringSlice(opts.Entrypoint)
+		}
+		taskDefinition, err = b.aws.RegisterTaskDefinition(ctx, registerTaskDefinitionInput(definition, tags))
+		if err != nil {
+			return 0, err
+		}
+		if opts.AutoRemove {
+			defer func() {
+				// running tasks are not impacted by task definition being deregistered
+				if err := b.aws.DeregisterTaskDefinition(context.Background(), taskDefinition); err != nil {
+					logrus.Warnf("failed to deregister task definition %s: %s", taskDefinition, err)
+				}
+			}()
+		}
+	}
+
+	override, err := runContainerOverride(opts)
+	if err != nil {
+		return 0, err
+	}
+	services, err := b.aws.DescribeServices(ctx, cluster, []string{serviceArn})
+	if err != nil {
+		return 0, err
+	}
+	if len(services) == 0 {
+		return 0, errors.Wrapf(api.ErrNotFound, "service %s does not exist in cluster %s", serviceArn, cluster)
+	}
+	taskArn, err := b.aws.RunTask(ctx, runTaskInput(cluster, services[0], taskDefinition, override, map[string]string{
+		api.ProjectLabel: project.Name,
+		api.ServiceLabel: opts.Service,
+		api.OneoffLabel:  "True",
+	}))
+	if err != nil {
+		return 0, err
+	}
+	if opts.Detach {
+		fmt.Fprintln(os.Stdout, taskArn)
+		return 0, nil
+	}
+
+	task, err := b.followTask(ctx, cluster, taskArn, container, os.Stdout)
+	if err != nil {
+		if ctx.Err() != nil && opts.AutoRemove {
+			if err := b.aws.StopTask(context.Background(), cluster, taskArn, "Interrupted by user"); err != nil {
+				logrus.Warnf("failed to stop task %s: %s", taskArn, err)
+			}
+		}
+		return 0, err
+	}
+	return taskExitCode(task, opts.Service)
+}
+
+// getServiceArn resolves the ECS service ARN for a compose service deployed by project stack
+func (b *ComposeECS) getServiceArn(ctx context.Context, project string, service string) (string, error) {
+	resources, err := b.aws.ListStackResources(ctx, project)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range resources {
+		if r.Type == awsTypeService && r.LogicalID == serviceResourceName(service) {
+			return r.ARN, nil
+		}
+	}
+	return "", errors.Wrapf(api.ErrNotFound, "service %q is not deployed by project %q", service, project)
+}
+
+func getContainerDefinition(definition *ecs.TaskDefinition, name string) (*ecs.ContainerDefinition, error) {
+	for _, c := range definition.ContainerDefinitions {
+		if aws.StringValue(c.Name) == name {
+			return c, nil
+		}
+	}
+	return nil, errors.Wrapf(api.ErrNotFound, "task definition %s has no container %q", aws.StringValue(definition.TaskDefinitionArn), name)
+}
+
+// awsTagPrefix is the prefix of tags managed by AWS, which can't be set by users
+const awsTagPrefix = "aws:"
+
+func registerTaskDefinitionInput(definition *ecs.TaskDefinition, tags []*ecs.Tag) *ecs.RegisterTaskDefinitionInput {
+	var userTags []*ecs.Tag
+	for _, t := range tags {
+		if !strings.HasPrefix(aws.StringValue(t.Key), awsTagPrefix) {
+			userTags = append(userTags, t)
+		}
+	}
+	return &ecs.RegisterTaskDefinitionInput{
+		ContainerDefinitions:    definition.ContainerDefinitions,
+		Cpu:                     definition.Cpu,
+		EphemeralStorage:        definition.EphemeralStorage,
+		ExecutionRoleArn:        definition.ExecutionRoleArn,
+		Family:                  definition.Family,
+		InferenceAccelerators:   definition.InferenceAccelerators,
+		IpcMode:                 definition.IpcMode,
+		Memory:                  definition.Memory,
+		NetworkMode:             definition.NetworkMode,
+		PidMode:                 definition.PidMode,
+		PlacementConstraints:    definition.PlacementConstraints,
+		ProxyConfiguration:      definition.ProxyConfiguration,
+		RequiresCompatibilities: definition.RequiresCompatibilities,
+		RuntimePlatform:         definition.RuntimePlatform,
+		Tags:                    userTags,
+		TaskRoleArn:             definition.TaskRoleArn,
+		Volumes:                 definition.Volumes,
+	}
+}
+
+// runTaskInput runs a task of taskDefinition the way service runs its tasks, in the same network, with the same launch type or
+// capacity providers. Those are set on the PRIMARY task set for blue/green services
+func runTaskInput(cluster string, service *ecs.Service, taskDefinition string, override *ecs.ContainerOverride, tags map[string]string) *ecs.RunTaskInput {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var taskTags []*ecs.Tag
+	for _, k := range keys {
+		taskTags = append(taskTags, &ecs.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(tags[k]),
+		})
+	}
+	_, network := serviceNetwork(service)
+	input := &ecs.RunTaskInput{
+		Cluster:              aws.String(cluster),
+		Count:                aws.Int64(1),
+		EnableExecuteCommand: service.EnableExecuteCommand,
+		NetworkConfiguration: network,
+		Overrides: &ecs.TaskOverride{
+			ContainerOverrides: []*ecs.ContainerOverride{override},
+		},
+		StartedBy:      aws.String(runTaskStartedBy),
+		Tags:           taskTags,
+		TaskDefinition: aws.String(taskDefinition),
+	}
+	capacityProviders, launchType, platformVersion := service.CapacityProviderStrategy, service.LaunchType, service.PlatformVersion
+	if taskSet := primaryTaskSet(service); isBlueGreenService(service) && taskSet != nil {
+		capacityProviders, launchType, platformVersion = taskSet.CapacityProviderStrategy, taskSet.LaunchType, taskSet.PlatformVersion
+	}
+	// launch type and capacity provider strategy are mutually exclusive
+	if len(capacityProviders) > 0 {
+		input.CapacityProviderStrategy = capacityProviders
+	} else {
+		input.LaunchType = launchType
+		input.PlatformVersion = platformVersion
+	}
+	return input
+}
+
+func runContainerOverride(opts api.RunOptions) (*ecs.ContainerOverride, error) {
+	override := &ecs.ContainerOverride{
+		Name: aws.String(opts.Service),
+	}
+	if len(opts.Command) > 0 {
+		override.Command = aws.StringSlice(opts.Command)
+	}
+	for _, e := range opts.Environment {
+		key, value, ok := strings.Cut(e, "=")
+		if !ok {
+			value, ok = os.LookupEnv(key)
+			if !ok {
+				continue
+			}
+		}
+		if key == "" {
+			return nil, fmt.Errorf("invalid environment variable %q", e)
+		}
+		override.Environment = append(override.Environment, &ecs.KeyValuePair{
+			Name:  aws.String(key),
+			Value: aws.String(value),
+		})
+	}
+	return override, nil
+}
+
+// followTask copies container logs to w until task is stopped
+func (b *ComposeECS) followTask(ctx context.Context, cluster string, taskArn string, container *ecs.ContainerDefinition, w io.Writer) (*ecs.Task, error) {
+	var group, stream string
+	if c := container.LogConfiguration; c != nil && aws.StringValue(c.LogDriver) == ecs.LogDriverAwslogs {
+		group = aws.StringValue(c.Options["awslogs-group"])
+		stream = fmt.Sprintf("%s/%s/%s", aws.StringValue(c.Options["awslogs-stream-prefix"]), aws.StringValue(container.Name), taskID(taskArn))
+	}
+
+	var token *string
+	copyLogs := func() error {
+		if group == "" {
+			return nil
+		}
+		var messages []string
+		var err error
+		messages, token, err = b.aws.GetLogEvents(ctx, group, stream, token)
+		if err != nil {
+			return err
+		}
+		for _, m := range messages {
+			fmt.Fprintln(w, m)
+		}
+		return nil
+	}
+
+	ticker := time.NewTicker(runTaskPollInterval)
+	defer ticker.Stop()
+	for {
+		task, err := b.aws.DescribeTask(ctx, cluster, taskArn)
+		if err != nil {
+			return nil, err
+		}
+		if err := copyLogs(); err != nil {
+			return nil, err
+		}
+		if aws.StringValue(task.LastStatus) == ecs.DesiredStatusStopped {
+			return task, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func taskExitCode(task *ecs.Task, name string) (int, error) {
+	for _, c := range task.Containers {
+		if aws.StringValue(c.Name) != name {
+			continue
+		}
+		if c.ExitCode == nil {
+			reason := aws.StringValue(c.Reason)
+			if reason == "" {
+				reason = aws.StringValue(task.StoppedReason)
+			}
+			return 0, fmt.Errorf("task %s stopped before container %q completed: %s", aws.StringValue(task.TaskArn), name, reason)
+		}
+		return int(aws.Int64Value(c.ExitCode)), nil
+	}
+	return 0, errors.Wrapf(api.ErrNotFound, "task %s has no container %q", aws.StringValue(task.TaskArn), name)
+}
+
+func checkUnsupportedRunOptions(ctx context.Context, o api.RunOptions) error {
+	var errs error
+	checks := []struct {
+		toCheck, expected interface{}
+		option            string
+	}{
+		{o.Privileged, false, "privileged"},
+		{len(o.Labels), 0, "label"},
+	}
+	for _, c := range checks {
+		errs = utils.CheckUnsupported(ctx, errs, c.toCheck, c.expected, "run", c.option)
+	}
+	return errs
+}
@@ -0,0 +1,217 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+const (
+	testTaskDefinition = "arn:aws:ecs:eu-west-3:123456789012:task-definition/TestRun-foo:1"
+	testTaskArn        = "arn:aws:ecs:eu-west-3:123456789012:task/cluster/0123456789"
+)
+
+// testServiceNetwork is the network configuration of services tasks run by `run` are started in
+var testServiceNetwork = &ecs.NetworkConfiguration{
+	AwsvpcConfiguration: &ecs.AwsVpcConfiguration{
+		AssignPublicIp: aws.String(ecs.AssignPublicIpEnabled),
+		SecurityGroups: aws.StringSlice([]string{"sg-123"}),
+		Subnets:        aws.StringSlice([]string{"subnet-123"}),
+	},
+}
+
+func expectRunTask(m *MockAPIMockRecorder, project string, service *ecs.Service) {
+	m.DescribeServices(gomock.Any(), "cluster", []string{"arn:service"}).Return([]*ecs.Service{service}, nil)
+	m.GetStackClusterID(gomock.Any(), project).Return("cluster", nil)
+	m.ListStackResources(gomock.Any(), project).Return(stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:service"},
+	}, nil)
+	m.GetServiceTaskDefinition(gomock.Any(), "cluster", []string{"arn:service"}).Return(map[string]string{
+		"arn:service": testTaskDefinition,
+	}, nil)
+	m.DescribeTaskDefinition(gomock.Any(), testTaskDefinition).Return(&ecs.TaskDefinition{
+		TaskDefinitionArn: aws.String(testTaskDefinition),
+		Family:            aws.String(project + "-foo"),
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{
+				Name: aws.String("foo"),
+				LogConfiguration: &ecs.LogConfiguration{
+					LogDriver: aws.String(ecs.LogDriverAwslogs),
+					Options: aws.StringMap(map[string]string{
+						"awslogs-group":         "/docker-compose/" + project,
+						"awslogs-stream-prefix": project,
+					}),
+				},
+			},
+		},
+	}, nil, nil)
+}
+
+func TestRunOneOffContainer(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+`)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectRunTask(m.EXPECT(), t.Name(), &ecs.Service{
+		ServiceArn:           aws.String("arn:service"),
+		LaunchType:           aws.String(ecs.LaunchTypeFargate),
+		NetworkConfiguration: testServiceNetwork,
+	})
+
+	m.EXPECT().RunTask(gomock.Any(), &ecs.RunTaskInput{
+		Cluster:              aws.String("cluster"),
+		Count:                aws.Int64(1),
+		LaunchType:           aws.String(ecs.LaunchTypeFargate),
+		NetworkConfiguration: testServiceNetwork,
+		Overrides: &ecs.TaskOverride{
+			ContainerOverrides: []*ecs.ContainerOverride{
+				{
+					Name:    aws.String("foo"),
+					Command: aws.StringSlice([]string{"migrate", "--all"}),
+					Environment: []*ecs.KeyValuePair{
+						{Name: aws.String("FOO"), Value: aws.String("BAR")},
+					},
+				},
+			},
+		},
+		StartedBy: aws.String(runTaskStartedBy),
+		Tags: []*ecs.Tag{
+			{Key: aws.String(api.OneoffLabel), Value: aws.String("True")},
+			{Key: aws.String(api.ProjectLabel), Value: aws.String(t.Name())},
+			{Key: aws.String(api.ServiceLabel), Value: aws.String("foo")},
+		},
+		TaskDefinition: aws.String(testTaskDefinition),
+	}).Return(testTaskArn, nil)
+	m.EXPECT().DescribeTask(gomock.Any(), "cluster", testTaskArn).Return(&ecs.Task{
+		TaskArn:    aws.String(testTaskArn),
+		LastStatus: aws.String(ecs.DesiredStatusStopped),
+		Containers: []*ecs.Container{
+			{Name: aws.String("foo"), ExitCode: aws.Int64(3)},
+		},
+	}, nil)
+	m.EXPECT().GetLogEvents(gomock.Any(), "/docker-compose/"+t.Name(), t.Name()+"/foo/0123456789", nil).Return([]string{"done"}, aws.String("f/1"), nil)
+
+	backend := &ComposeECS{aws: m}
+	exitCode, err := backend.RunOneOffContainer(context.TODO(), project, api.RunOptions{
+		Service:     "foo",
+		Command:     []string{"migrate", "--all"},
+		Environment: []string{"FOO=BAR"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 3)
+}
+
+func TestRunOneOffContainerWithUser(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+`)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectRunTask(m.EXPECT(), t.Name(), &ecs.Service{ServiceArn: aws.String("arn:service")})
+
+	const revision = "arn:aws:ecs:eu-west-3:123456789012:task-definition/TestRun-foo:2"
+	m.EXPECT().RegisterTaskDefinition(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input *ecs.RegisterTaskDefinitionInput) (string, error) {
+		assert.Equal(t, aws.StringValue(input.Family), t.Name()+"-foo")
+		assert.Equal(t, aws.StringValue(input.ContainerDefinitions[0].User), "root")
+		return revision, nil
+	})
+	m.EXPECT().RunTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input *ecs.RunTaskInput) (string, error) {
+		assert.Equal(t, aws.StringValue(input.TaskDefinition), revision)
+		return testTaskArn, nil
+	})
+	m.EXPECT().DeregisterTaskDefinition(gomock.Any(), revision).Return(nil)
+
+	backend := &ComposeECS{aws: m}
+	exitCode, err := backend.RunOneOffContainer(context.TODO(), project, api.RunOptions{
+		Service:    "foo",
+		User:       "root",
+		Detach:     true,
+		AutoRemove: true,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 0)
+}
+
+func TestRunOneOffContainerBlueGreen(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+`)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectRunTask(m.EXPECT(), t.Name(), &ecs.Service{
+		ServiceArn:           aws.String("arn:service"),
+		DeploymentController: &ecs.DeploymentController{Type: aws.String(ecs.DeploymentControllerTypeExternal)},
+		TaskSets: []*ecs.TaskSet{
+			{Status: aws.String("ACTIVE"), LaunchType: aws.String(ecs.LaunchTypeEc2)},
+			{Status: aws.String("PRIMARY"), LaunchType: aws.String(ecs.LaunchTypeFargate), NetworkConfiguration: testServiceNetwork},
+		},
+	})
+
+	m.EXPECT().RunTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input *ecs.RunTaskInput) (string, error) {
+		assert.DeepEqual(t, input.NetworkConfiguration, testServiceNetwork)
+		assert.Equal(t, aws.StringValue(input.LaunchType), ecs.LaunchTypeFargate)
+		return testTaskArn, nil
+	})
+
+	backend := &ComposeECS{aws: m}
+	exitCode, err := backend.RunOneOffContainer(context.TODO(), project, api.RunOptions{
+		Service: "foo",
+		Detach:  true,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 0)
+}
+
+func TestRegisterTaskDefinitionInputSkipsAWSTags(t *testing.T) {
+	input := registerTaskDefinitionInput(&ecs.TaskDefinition{
+		Family: aws.String("TestRun-foo"),
+	}, []*ecs.Tag{
+		{Key: aws.String(api.ProjectLabel), Value: aws.String("TestRun")},
+		{Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("TestRun")},
+	})
+	assert.DeepEqual(t, input.Tags, []*ecs.Tag{
+		{Key: aws.String(api.ProjectLabel), Value: aws.String("TestRun")},
+	})
+}
+
+func TestTaskExitCodeWithoutContainerExit(t *testing.T) {
+	_, err := taskExitCode(&ecs.Task{
+		TaskArn:       aws.String(testTaskArn),
+		StoppedReason: aws.String("Essential container in task exited"),
+		Containers: []*ecs.Container{
+			{Name: aws.String("foo"), Reason: aws.String("CannotPullContainerError: pull image manifest has been retried 5 time(s)")},
+		},
+	}, "foo")
+	assert.ErrorContains(t, err, "CannotPullContainerError")
+}
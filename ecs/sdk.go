@@ -21,17 +21,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go/service/applicationautoscaling/applicationautoscalingiface"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -51,11 +57,14 @@ import (
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/ssm"
 	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-uuid"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/docker/compose-ecs/api/secrets"
 	"github.com/docker/compose-ecs/internal"
@@ -67,12 +76,15 @@ type sdk struct {
 	EFS      efsiface.EFSAPI
 	ELB      elbv2iface.ELBV2API
 	CW       cloudwatchlogsiface.CloudWatchLogsAPI
+	CWM      cloudwatchiface.CloudWatchAPI
 	IAM      iamiface.IAMAPI
 	CF       cloudformationiface.CloudFormationAPI
 	SM       secretsmanageriface.SecretsManagerAPI
 	SSM      ssmiface.SSMAPI
 	AG       autoscalingiface.AutoScalingAPI
+	AAS      applicationautoscalingiface.ApplicationAutoScalingAPI
 	S3       s3iface.S3API
+	STS      stsiface.STSAPI
 	uploader *s3manager.Uploader
 }
 
@@ -92,12 +104,15 @@ func newSDK(sess *session.Session) sdk {
 		EFS:      efs.New(sess),
 		ELB:      elbv2.New(sess),
 		CW:       cloudwatchlogs.New(sess),
+		CWM:      cloudwatch.New(sess),
 		IAM:      iam.New(sess),
 		CF:       cloudformation.New(sess),
 		SM:       secretsmanager.New(sess),
 		SSM:      ssm.New(sess),
 		AG:       autoscaling.New(sess),
+		AAS:      applicationautoscaling.New(sess),
 		S3:       s3.New(sess),
+		STS:      sts.New(sess),
 		uploader: s3manager.NewUploader(sess),
 	}
 }
@@ -268,6 +283,12 @@ func (s sdk) StackExists(ctx context.Context, name string) (bool, error) {
 
 type uploadedTemplateFunc func(body *string, url *string) (string, error)
 
+const (
+	// templateBucketPrefix is the name prefix of the S3 buckets we create to upload large templates
+	templateBucketPrefix = "com.docker.compose."
+	templateObjectKey    = "template.yaml"
+)
+
 const cloudformationBytesLimit = 51200
 
 func (s sdk) withTemplate(ctx context.Context, name string, template []byte, region string, fn uploadedTemplateFunc) (string, error) {
@@ -279,7 +300,7 @@ func (s sdk) withTemplate(ctx context.Context, name string, template []byte, reg
 	if err != nil {
 		return "", err
 	}
-	bucket := "com.docker.compose." + key
+	bucket := templateBucketPrefix + key
 	logrus.Debugf("Create s3 bucket %q to store cloudformation template", bucket)
 
 	var configuration *s3.CreateBucketConfiguration
@@ -295,9 +316,24 @@ func (s sdk) withTemplate(ctx context.Context, name string, template []byte, reg
 	if err != nil {
 		return "", err
 	}
+	// bucket is tagged so it can be removed as an orphan by `--remove-orphans` if we fail to delete it
+	_, err = s.S3.PutBucketTaggingWithContext(ctx, &s3.PutBucketTaggingInput{
+		Bucket: aws.String(bucket),
+		Tagging: &s3.Tagging{
+			TagSet: []*s3.Tag{
+				{
+					Key:   aws.String(api.ProjectLabel),
+					Value: aws.String(name),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
 
 	upload, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
-		Key:         aws.String("template.yaml"),
+		Key:         aws.String(templateObjectKey),
 		Body:        bytes.NewReader(template),
 		Bucket:      aws.String(bucket),
 		ContentType: aws.String("application/x-yaml"),
@@ -311,7 +347,7 @@ func (s sdk) withTemplate(ctx context.Context, name string, template []byte, reg
 	defer func() {
 		_, err := s.S3.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
 			Bucket:    aws.String(bucket),
-			Key:       aws.String("template.yaml"),
+			Key:       aws.String(templateObjectKey),
 			VersionId: upload.VersionID,
 		})
 		if err != nil {
@@ -340,6 +376,8 @@ func (s sdk) CreateStack(ctx context.Context, name string, region string, templa
 			TimeoutInMinutes: nil,
 			Capabilities: []*string{
 				aws.String(cloudformation.CapabilityCapabilityIam),
+				// required to create a stack without a change set when template uses a transform, as blue/green deployments do
+				aws.String(cloudformation.CapabilityCapabilityAutoExpand),
 			},
 			Tags: []*cloudformation.Tag{
 				{
@@ -357,21 +395,39 @@ func (s sdk) CreateStack(ctx context.Context, name string, region string, templa
 	return err
 }
 
-func (s sdk) CreateChangeSet(ctx context.Context, name string, region string, template []byte) (string, error) {
+// noChangesStatusReason is the status reason of a change set which failed because template didn't change
+const noChangesStatusReason = "The submitted information didn't contain changes."
+
+// CreateChangeSet creates a change set of given type for stack, and waits for it to be created. Change set without changes is not
+// an error, as we check for this before executing it.
+func (s sdk) CreateChangeSet(ctx context.Context, name string, changeSetType string, region string, template []byte) (string, error) {
 	logrus.Debug("Create CloudFormation Changeset")
-	update := fmt.Sprintf("Update%s", time.Now().Format("2006-01-02-15-04-05"))
+	prefix := "Update"
+	if changeSetType == cloudformation.ChangeSetTypeCreate {
+		prefix = "Create"
+	}
+	update := fmt.Sprintf("%s%s", prefix, time.Now().Format("2006-01-02-15-04-05"))
 
 	changeset, err := s.withTemplate(ctx, name, template, region, func(body *string, url *string) (string, error) {
-		changeset, err := s.CF.CreateChangeSetWithContext(ctx, &cloudformation.CreateChangeSetInput{
+		input := &cloudformation.CreateChangeSetInput{
 			ChangeSetName: aws.String(update),
-			ChangeSetType: aws.String(cloudformation.ChangeSetTypeUpdate),
+			ChangeSetType: aws.String(changeSetType),
 			StackName:     aws.String(name),
 			TemplateBody:  body,
 			TemplateURL:   url,
 			Capabilities: []*string{
 				aws.String(cloudformation.CapabilityCapabilityIam),
 			},
-		})
+		}
+		if changeSetType == cloudformation.ChangeSetTypeCreate {
+			input.Tags = []*cloudformation.Tag{
+				{
+					Key:   aws.String(api.ProjectLabel),
+					Value: aws.String(name),
+				},
+			}
+		}
+		changeset, err := s.CF.CreateChangeSetWithContext(ctx, input)
 		if err != nil {
 			return "", err
 		}
@@ -391,11 +447,71 @@ func (s sdk) CreateChangeSet(ctx context.Context, name string, region string, te
 		ChangeSetName: aws.String(update),
 		StackName:     aws.String(name),
 	})
-	if aws.StringValue(desc.Status) == "FAILED" {
+	if err != nil {
+		return changeset, err
+	}
+	if aws.StringValue(desc.Status) == cloudformation.ChangeSetStatusFailed && !strings.HasPrefix(aws.StringValue(desc.StatusReason), noChangesStatusReason) {
 		return changeset, fmt.Errorf(aws.StringValue(desc.StatusReason))
 	}
 
-	return changeset, err
+	return changeset, nil
+}
+
+// DescribeChangeSet returns change set status and the resource changes it applies. Stack is only required when changeset is a name
+func (s sdk) DescribeChangeSet(ctx context.Context, stack string, changeset string) (changeSet, error) {
+	input := &cloudformation.DescribeChangeSetInput{
+		ChangeSetName: aws.String(changeset),
+	}
+	if stack != "" {
+		input.StackName = aws.String(stack)
+	}
+	var cs changeSet
+	for {
+		desc, err := s.CF.DescribeChangeSetWithContext(ctx, input)
+		if err != nil {
+			return changeSet{}, err
+		}
+		cs.ID = aws.StringValue(desc.ChangeSetId)
+		cs.Name = aws.StringValue(desc.ChangeSetName)
+		cs.StackName = aws.StringValue(desc.StackName)
+		cs.Status = aws.StringValue(desc.Status)
+		cs.ExecutionStatus = aws.StringValue(desc.ExecutionStatus)
+		cs.StatusReason = aws.StringValue(desc.StatusReason)
+		for _, c := range desc.Changes {
+			if c.ResourceChange != nil {
+				cs.Changes = append(cs.Changes, toResourceChange(c.ResourceChange))
+			}
+		}
+		if desc.NextToken == nil {
+			return cs, nil
+		}
+		input.NextToken = desc.NextToken
+	}
+}
+
+func toResourceChange(rc *cloudformation.ResourceChange) resourceChange {
+	change := resourceChange{
+		Action:      aws.StringValue(rc.Action),
+		LogicalID:   aws.StringValue(rc.LogicalResourceId),
+		Type:        aws.StringValue(rc.ResourceType),
+		Replacement: aws.StringValue(rc.Replacement),
+	}
+	known := map[string]bool{}
+	for _, d := range rc.Details {
+		if d.Target == nil {
+			continue
+		}
+		// Name is only set for properties, other attributes (Tags, Metadata...) are changed as a whole
+		name := aws.StringValue(d.Target.Name)
+		if name == "" {
+			name = aws.StringValue(d.Target.Attribute)
+		}
+		if name != "" && !known[name] {
+			known[name] = true
+			change.Properties = append(change.Properties, name)
+		}
+	}
+	return change
 }
 
 func (s sdk) UpdateStack(ctx context.Context, changeset string) error {
@@ -406,7 +522,7 @@ func (s sdk) UpdateStack(ctx context.Context, changeset string) error {
 		return err
 	}
 
-	if strings.HasPrefix(aws.StringValue(desc.StatusReason), "The submitted information didn't contain changes.") {
+	if strings.HasPrefix(aws.StringValue(desc.StatusReason), noChangesStatusReason) {
 		return nil
 	}
 
@@ -422,6 +538,8 @@ const (
 	stackDelete
 )
 
+const stackPollInterval = 5 * time.Second
+
 func (s sdk) WaitStackComplete(ctx context.Context, name string, operation int) error {
 	input := &cloudformation.DescribeStacksInput{
 		StackName: aws.String(name),
@@ -429,6 +547,9 @@ func (s sdk) WaitStackComplete(ctx context.Context, name string, operation int)
 	switch operation {
 	case stackCreate:
 		return s.CF.WaitUntilStackCreateCompleteWithContext(ctx, input)
+	case stackUpdate:
+		// update waiter doesn't accept stack status when change set had no change, and we also wait for update to be rolled back
+		return s.waitStackNotInProgress(ctx, name)
 	case stackDelete:
 		return s.CF.WaitUntilStackDeleteCompleteWithContext(ctx, input)
 	default:
@@ -436,6 +557,65 @@ func (s sdk) WaitStackComplete(ctx context.Context, name string, operation int)
 	}
 }
 
+// waitStackNotInProgress waits for stack to reach a final status, whatever it is
+func (s sdk) waitStackNotInProgress(ctx context.Context, name string) error {
+	ticker := time.NewTicker(stackPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		stacks, err := s.CF.DescribeStacksWithContext(ctx, &cloudformation.DescribeStacksInput{
+			StackName: aws.String(name),
+		})
+		if err != nil {
+			return err
+		}
+		if len(stacks.Stacks) == 0 || !strings.HasSuffix(aws.StringValue(stacks.Stacks[0].StackStatus), "_IN_PROGRESS") {
+			return nil
+		}
+	}
+}
+
+func (s sdk) GetStackStatus(ctx context.Context, name string) (string, error) {
+	stacks, err := s.CF.DescribeStacksWithContext(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(name),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(stacks.Stacks) == 0 {
+		return "", errors.Wrapf(api.ErrNotFound, "stack %s doesn't exist", name)
+	}
+	return aws.StringValue(stacks.Stacks[0].StackStatus), nil
+}
+
+func (s sdk) CancelUpdateStack(ctx context.Context, name string) error {
+	logrus.Debug("Cancel update of CloudFormation stack ", name)
+	_, err := s.CF.CancelUpdateStackWithContext(ctx, &cloudformation.CancelUpdateStackInput{
+		StackName: aws.String(name),
+	})
+	return err
+}
+
+// GetTemplate returns the template of stack as it was submitted, or the one of changeset if set
+func (s sdk) GetTemplate(ctx context.Context, name string, changeset string) ([]byte, error) {
+	input := &cloudformation.GetTemplateInput{
+		StackName:     aws.String(name),
+		TemplateStage: aws.String(cloudformation.TemplateStageOriginal),
+	}
+	if changeset != "" {
+		input.ChangeSetName = aws.String(changeset)
+	}
+	template, err := s.CF.GetTemplateWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(aws.StringValue(template.TemplateBody)), nil
+}
+
 func (s sdk) GetStackID(ctx context.Context, name string) (string, error) {
 	stacks, err := s.CF.DescribeStacksWithContext(ctx, &cloudformation.DescribeStacksInput{
 		StackName: aws.String(name),
@@ -446,42 +626,105 @@ func (s sdk) GetStackID(ctx context.Context, name string) (string, error) {
 	return *stacks.Stacks[0].StackId, nil
 }
 
-func (s sdk) ListStacks(ctx context.Context) ([]api.Stack, error) {
+const (
+	// deletedStacksWindow bounds the deleted stacks listed, as CloudFormation keeps them for 90 days and each must be described
+	deletedStacksWindow = 7 * 24 * time.Hour
+	// describeStacksConcurrency is the maximum number of deleted stacks described concurrently
+	describeStacksConcurrency = 5
+)
+
+func (s sdk) ListStacks(ctx context.Context, all bool) ([]projectStack, error) {
 	params := cloudformation.DescribeStacksInput{}
-	var token *string
-	var stacks []api.Stack
+	var stacks []projectStack
 	for {
 		response, err := s.CF.DescribeStacksWithContext(ctx, &params)
 		if err != nil {
 			return nil, err
 		}
 		for _, stack := range response.Stacks {
-			for _, t := range stack.Tags {
-				if *t.Key == api.ProjectLabel {
-					status := api.RUNNING
-					switch aws.StringValue(stack.StackStatus) {
-					case "CREATE_IN_PROGRESS":
-						status = api.STARTING
-					case "DELETE_IN_PROGRESS":
-						status = api.REMOVING
-					case "UPDATE_IN_PROGRESS":
-						status = api.UPDATING
-					default:
-					}
-					stacks = append(stacks, api.Stack{
-						ID:     aws.StringValue(stack.StackId),
-						Name:   aws.StringValue(stack.StackName),
-						Status: status,
-					})
-					break
-				}
+			if p, ok := toProjectStack(stack); ok && (all || p.Status != api.FAILED) {
+				stacks = append(stacks, p)
 			}
 		}
-		if token == response.NextToken {
-			return stacks, nil
+		if response.NextToken == nil {
+			break
+		}
+		params.NextToken = response.NextToken
+	}
+	if !all {
+		return stacks, nil
+	}
+
+	// deleted stacks are only listed by ListStacks, which doesn't return tags, so we describe recently deleted ones by unique ID
+	var deleted []string
+	since := time.Now().Add(-deletedStacksWindow)
+	err := s.CF.ListStacksPagesWithContext(ctx, &cloudformation.ListStacksInput{
+		StackStatusFilter: aws.StringSlice([]string{cloudformation.StackStatusDeleteComplete}),
+	}, func(page *cloudformation.ListStacksOutput, lastPage bool) bool {
+		for _, summary := range page.StackSummaries {
+			if aws.TimeValue(summary.DeletionTime).After(since) {
+				deleted = append(deleted, aws.StringValue(summary.StackId))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// stacks are collected by index, so output order doesn't depend on which request completes first
+	described := make([][]*cloudformation.Stack, len(deleted))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(describeStacksConcurrency)
+	for i, id := range deleted {
+		i, id := i, id
+		eg.Go(func() error {
+			response, err := s.CF.DescribeStacksWithContext(ctx, &cloudformation.DescribeStacksInput{
+				StackName: aws.String(id),
+			})
+			if err != nil {
+				return err
+			}
+			described[i] = response.Stacks
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	for _, d := range described {
+		for _, stack := range d {
+			if p, ok := toProjectStack(stack); ok {
+				stacks = append(stacks, p)
+			}
 		}
-		token = response.NextToken
 	}
+	return stacks, nil
+}
+
+// toProjectStack converts a CloudFormation stack deployed for a compose project
+func toProjectStack(stack *cloudformation.Stack) (projectStack, bool) {
+	for _, t := range stack.Tags {
+		if aws.StringValue(t.Key) != api.ProjectLabel {
+			continue
+		}
+		p := projectStack{
+			Stack: api.Stack{
+				ID:     aws.StringValue(stack.StackId),
+				Name:   aws.StringValue(stack.StackName),
+				Status: stackStatus(aws.StringValue(stack.StackStatus)),
+			},
+			LastUpdated: aws.TimeValue(stack.CreationTime),
+		}
+		if p.Status == api.FAILED {
+			p.Reason = aws.StringValue(stack.StackStatusReason)
+		}
+		if stack.LastUpdatedTime != nil {
+			p.LastUpdated = aws.TimeValue(stack.LastUpdatedTime)
+		}
+		return p, true
+	}
+	return projectStack{}, false
 }
 
 func (s sdk) GetStackClusterID(ctx context.Context, stack string) (string, error) {
@@ -553,12 +796,45 @@ func (s sdk) GetServiceTaskDefinition(ctx context.Context, cluster string, servi
 			return nil, err
 		}
 		for _, s := range services.Services {
-			defs[aws.StringValue(s.ServiceArn)] = aws.StringValue(s.TaskDefinition)
+			defs[aws.StringValue(s.ServiceArn)] = serviceTaskDefinition(s)
 		}
 	}
 	return defs, nil
 }
 
+// serviceTaskDefinition returns the task definition of service, which is set by its primary task set when deployed by CodeDeploy
+func serviceTaskDefinition(service *ecs.Service) string {
+	if definition := aws.StringValue(service.TaskDefinition); definition != "" {
+		return definition
+	}
+	if taskSet := primaryTaskSet(service); taskSet != nil {
+		return aws.StringValue(taskSet.TaskDefinition)
+	}
+	return ""
+}
+
+// primaryTaskSet returns the task set which serves traffic for blue/green services, if any
+func primaryTaskSet(service *ecs.Service) *ecs.TaskSet {
+	for _, taskSet := range service.TaskSets {
+		if aws.StringValue(taskSet.Status) == "PRIMARY" {
+			return taskSet
+		}
+	}
+	return nil
+}
+
+// serviceNetwork returns the load balancers and network configuration of service, which are set on the PRIMARY task set for blue/green
+// services
+func serviceNetwork(service *ecs.Service) ([]*ecs.LoadBalancer, *ecs.NetworkConfiguration) {
+	if !isBlueGreenService(service) {
+		return service.LoadBalancers, service.NetworkConfiguration
+	}
+	if taskSet := primaryTaskSet(service); taskSet != nil {
+		return taskSet.LoadBalancers, taskSet.NetworkConfiguration
+	}
+	return nil, nil
+}
+
 func (s sdk) ListStackServices(ctx context.Context, stack string) ([]string, error) {
 	arns := []string{}
 	var nextToken *string
@@ -597,6 +873,7 @@ func (s sdk) GetServiceTasks(ctx context.Context, cluster string, service string
 			Cluster:       aws.String(cluster),
 			ServiceName:   aws.String(service),
 			DesiredStatus: aws.String(state),
+			NextToken:     token,
 		})
 		if err != nil {
 			return nil, err
@@ -604,6 +881,7 @@ func (s sdk) GetServiceTasks(ctx context.Context, cluster string, service string
 		if len(response.TaskArns) > 0 {
 			taskDescriptions, err := s.ECS.DescribeTasksWithContext(ctx, &ecs.DescribeTasksInput{
 				Cluster: aws.String(cluster),
+				Include: aws.StringSlice([]string{"TAGS"}),
 				Tasks:   response.TaskArns,
 			})
 			if err != nil {
@@ -611,30 +889,461 @@ func (s sdk) GetServiceTasks(ctx context.Context, cluster string, service string
 			}
 			tasks = append(tasks, taskDescriptions.Tasks...)
 		}
-		if token == response.NextToken {
-			return tasks, nil
+		if response.NextToken == nil {
+			return tasks, nil
+		}
+		token = response.NextToken
+	}
+}
+
+func (s sdk) GetTaskStoppedReason(ctx context.Context, cluster string, taskArn string) (string, error) {
+	taskDescriptions, err := s.ECS.DescribeTasksWithContext(ctx, &ecs.DescribeTasksInput{
+		Cluster: aws.String(cluster),
+		Tasks:   []*string{aws.String(taskArn)},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(taskDescriptions.Tasks) == 0 {
+		return "", nil
+	}
+	task := taskDescriptions.Tasks[0]
+	return fmt.Sprintf(
+		"%s: %s",
+		aws.StringValue(task.StopCode),
+		aws.StringValue(task.StoppedReason)), nil
+
+}
+
+func (s sdk) ExecuteCommand(ctx context.Context, cluster string, taskArn string, container string, command string) (*ecs.Session, error) {
+	logrus.Debug("Execute command ", command, " in ", taskArn)
+	response, err := s.ECS.ExecuteCommandWithContext(ctx, &ecs.ExecuteCommandInput{
+		Cluster:     aws.String(cluster),
+		Command:     aws.String(command),
+		Container:   aws.String(container),
+		Interactive: aws.Bool(true),
+		Task:        aws.String(taskArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Session, nil
+}
+
+func (s sdk) DescribeTask(ctx context.Context, cluster string, taskArn string) (*ecs.Task, error) {
+	taskDescriptions, err := s.ECS.DescribeTasksWithContext(ctx, &ecs.DescribeTasksInput{
+		Cluster: aws.String(cluster),
+		Tasks:   []*string{aws.String(taskArn)},
+		Include: aws.StringSlice([]string{"TAGS"}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range taskDescriptions.Failures {
+		return nil, errors.Wrapf(api.ErrNotFound, "can't get task %s: %s", aws.StringValue(f.Arn), aws.StringValue(f.Reason))
+	}
+	if len(taskDescriptions.Tasks) == 0 {
+		return nil, errors.Wrapf(api.ErrNotFound, "task %s not found", taskArn)
+	}
+	return taskDescriptions.Tasks[0], nil
+}
+
+func (s sdk) DescribeTaskDefinition(ctx context.Context, arn string) (*ecs.TaskDefinition, []*ecs.Tag, error) {
+	response, err := s.ECS.DescribeTaskDefinitionWithContext(ctx, &ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(arn),
+		Include:        aws.StringSlice([]string{"TAGS"}),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.TaskDefinition, response.Tags, nil
+}
+
+func (s sdk) RegisterTaskDefinition(ctx context.Context, input *ecs.RegisterTaskDefinitionInput) (string, error) {
+	logrus.Debug("Register task definition ", aws.StringValue(input.Family))
+	response, err := s.ECS.RegisterTaskDefinitionWithContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(response.TaskDefinition.TaskDefinitionArn), nil
+}
+
+func (s sdk) DeregisterTaskDefinition(ctx context.Context, arn string) error {
+	logrus.Debug("Deregister task definition ", arn)
+	_, err := s.ECS.DeregisterTaskDefinitionWithContext(ctx, &ecs.DeregisterTaskDefinitionInput{
+		TaskDefinition: aws.String(arn),
+	})
+	return err
+}
+
+func (s sdk) ListProjectTaskDefinitions(ctx context.Context, project string) ([]string, error) {
+	var families []string
+	err := s.ECS.ListTaskDefinitionFamiliesPagesWithContext(ctx, &ecs.ListTaskDefinitionFamiliesInput{
+		FamilyPrefix: aws.String(project + "-"),
+		Status:       aws.String(ecs.TaskDefinitionFamilyStatusActive),
+	}, func(page *ecs.ListTaskDefinitionFamiliesOutput, lastPage bool) bool {
+		families = append(families, aws.StringValueSlice(page.Families)...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var arns []string
+	for _, family := range families {
+		var revisions []string
+		err := s.ECS.ListTaskDefinitionsPagesWithContext(ctx, &ecs.ListTaskDefinitionsInput{
+			FamilyPrefix: aws.String(family),
+			Status:       aws.String(ecs.TaskDefinitionStatusActive),
+			Sort:         aws.String(ecs.SortOrderDesc),
+		}, func(page *ecs.ListTaskDefinitionsOutput, lastPage bool) bool {
+			for _, arn := range page.TaskDefinitionArns {
+				// family prefix also matches families with a longer name
+				if taskDefinitionFamily(aws.StringValue(arn)) == family {
+					revisions = append(revisions, aws.StringValue(arn))
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(revisions) == 0 {
+			continue
+		}
+		// family prefix could match another project, so we check latest revision is tagged by project
+		_, tags, err := s.DescribeTaskDefinition(ctx, revisions[0])
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tags {
+			if aws.StringValue(t.Key) == api.ProjectLabel && aws.StringValue(t.Value) == project {
+				arns = append(arns, revisions...)
+				break
+			}
+		}
+	}
+	return arns, nil
+}
+
+// taskDefinitionFamily returns the family of a task definition ARN like arn:aws:ecs:region:account:task-definition/family:revision
+func taskDefinitionFamily(arn string) string {
+	family := arn[strings.LastIndex(arn, "/")+1:]
+	if i := strings.LastIndex(family, ":"); i >= 0 {
+		family = family[:i]
+	}
+	return family
+}
+
+func (s sdk) ListTemplateBuckets(ctx context.Context, project string) ([]templateBucket, error) {
+	response, err := s.S3.ListBucketsWithContext(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+	var buckets []templateBucket
+	for _, b := range response.Buckets {
+		name := aws.StringValue(b.Name)
+		if !strings.HasPrefix(name, templateBucketPrefix) {
+			continue
+		}
+		owned, err := s.isProjectTemplateBucket(ctx, name, project)
+		if err != nil {
+			return nil, err
+		}
+		if owned {
+			buckets = append(buckets, templateBucket{
+				Name:    name,
+				Created: aws.TimeValue(b.CreationDate),
+			})
+		}
+	}
+	return buckets, nil
+}
+
+// isProjectTemplateBucket checks bucket tags, or template object tags for buckets created by older releases
+func (s sdk) isProjectTemplateBucket(ctx context.Context, bucket string, project string) (bool, error) {
+	tagging, err := s.S3.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if err == nil {
+		for _, t := range tagging.TagSet {
+			if aws.StringValue(t.Key) == api.ProjectLabel {
+				return aws.StringValue(t.Value) == project, nil
+			}
+		}
+	} else if !isIgnoredBucketError(err) {
+		return false, err
+	}
+
+	objectTagging, err := s.S3.GetObjectTaggingWithContext(ctx, &s3.GetObjectTaggingInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(templateObjectKey),
+	})
+	if err != nil {
+		if isIgnoredBucketError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, t := range objectTagging.TagSet {
+		if aws.StringValue(t.Key) == project {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isIgnoredBucketError returns true for errors telling a bucket has no tags, no template, or is in another region
+func isIgnoredBucketError(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case "NoSuchTagSet", s3.ErrCodeNoSuchBucket, s3.ErrCodeNoSuchKey, "PermanentRedirect", "AuthorizationHeaderMalformed", "AccessDenied":
+			return true
+		}
+	}
+	return false
+}
+
+func (s sdk) DeleteBucket(ctx context.Context, bucket string) error {
+	err := s.S3.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, o := range page.Contents {
+			_, err := s.S3.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    o.Key,
+			})
+			if err != nil {
+				logrus.Warnf("Failed to remove S3 object %s/%s: %s", bucket, aws.StringValue(o.Key), err)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.S3.DeleteBucketWithContext(ctx, &s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	return err
+}
+
+func (s sdk) RunTask(ctx context.Context, input *ecs.RunTaskInput) (string, error) {
+	logrus.Debug("Run task ", aws.StringValue(input.TaskDefinition))
+	response, err := s.ECS.RunTaskWithContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range response.Failures {
+		return "", fmt.Errorf("failed to run task: %s %s", aws.StringValue(f.Reason), aws.StringValue(f.Detail))
+	}
+	if len(response.Tasks) == 0 {
+		return "", fmt.Errorf("failed to run task: no task started for %s", aws.StringValue(input.TaskDefinition))
+	}
+	return aws.StringValue(response.Tasks[0].TaskArn), nil
+}
+
+func (s sdk) StopTask(ctx context.Context, cluster string, taskArn string, reason string) error {
+	logrus.Debug("Stop task ", taskArn)
+	_, err := s.ECS.StopTaskWithContext(ctx, &ecs.StopTaskInput{
+		Cluster: aws.String(cluster),
+		Reason:  aws.String(reason),
+		Task:    aws.String(taskArn),
+	})
+	return err
+}
+
+func (s sdk) ScaleService(ctx context.Context, cluster string, serviceArn string, desiredCount int) error {
+	logrus.Debugf("Scale service %s to %d", serviceArn, desiredCount)
+	_, err := s.ECS.UpdateServiceWithContext(ctx, &ecs.UpdateServiceInput{
+		Cluster:      aws.String(cluster),
+		DesiredCount: aws.Int64(int64(desiredCount)),
+		Service:      aws.String(serviceArn),
+	})
+	return err
+}
+
+func (s sdk) WaitServicesStable(ctx context.Context, cluster string, serviceArns []string) error {
+	for i := 0; i < len(serviceArns); i += 10 {
+		end := i + 10
+		if end > len(serviceArns) {
+			end = len(serviceArns)
+		}
+		err := s.ECS.WaitUntilServicesStableWithContext(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Services: aws.StringSlice(serviceArns[i:end]),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s sdk) GetServiceTags(ctx context.Context, serviceArn string) (map[string]string, error) {
+	response, err := s.ECS.ListTagsForResourceWithContext(ctx, &ecs.ListTagsForResourceInput{
+		ResourceArn: aws.String(serviceArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]string{}
+	for _, t := range response.Tags {
+		tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	return tags, nil
+}
+
+func (s sdk) TagService(ctx context.Context, serviceArn string, tags map[string]string) error {
+	var ecsTags []*ecs.Tag
+	for k, v := range tags {
+		ecsTags = append(ecsTags, &ecs.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		})
+	}
+	_, err := s.ECS.TagResourceWithContext(ctx, &ecs.TagResourceInput{
+		ResourceArn: aws.String(serviceArn),
+		Tags:        ecsTags,
+	})
+	return err
+}
+
+func (s sdk) UntagService(ctx context.Context, serviceArn string, keys []string) error {
+	_, err := s.ECS.UntagResourceWithContext(ctx, &ecs.UntagResourceInput{
+		ResourceArn: aws.String(serviceArn),
+		TagKeys:     aws.StringSlice(keys),
+	})
+	return err
+}
+
+// scalableTargetID is the Application Auto Scaling resource ID for an ECS service: service/<cluster>/<service>
+func scalableTargetID(serviceArn string) (string, error) {
+	parsed, err := arn.Parse(serviceArn)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Resource, nil
+}
+
+func (s sdk) GetServiceScalableTarget(ctx context.Context, serviceArn string) (*applicationautoscaling.ScalableTarget, error) {
+	id, err := scalableTargetID(serviceArn)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.AAS.DescribeScalableTargetsWithContext(ctx, &applicationautoscaling.DescribeScalableTargetsInput{
+		ResourceIds:       aws.StringSlice([]string{id}),
+		ScalableDimension: aws.String(applicationautoscaling.ScalableDimensionEcsServiceDesiredCount),
+		ServiceNamespace:  aws.String(applicationautoscaling.ServiceNamespaceEcs),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(response.ScalableTargets) == 0 {
+		return nil, nil
+	}
+	return response.ScalableTargets[0], nil
+}
+
+func (s sdk) UpdateServiceScalableTarget(ctx context.Context, serviceArn string, minCapacity int64, suspended bool) error {
+	id, err := scalableTargetID(serviceArn)
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("Update scalable target %s min capacity to %d, suspended: %t", id, minCapacity, suspended)
+	_, err = s.AAS.RegisterScalableTargetWithContext(ctx, &applicationautoscaling.RegisterScalableTargetInput{
+		MinCapacity:       aws.Int64(minCapacity),
+		ResourceId:        aws.String(id),
+		ScalableDimension: aws.String(applicationautoscaling.ScalableDimensionEcsServiceDesiredCount),
+		ServiceNamespace:  aws.String(applicationautoscaling.ServiceNamespaceEcs),
+		SuspendedState: &applicationautoscaling.SuspendedState{
+			DynamicScalingInSuspended:  aws.Bool(suspended),
+			DynamicScalingOutSuspended: aws.Bool(suspended),
+			ScheduledScalingSuspended:  aws.Bool(suspended),
+		},
+	})
+	return err
+}
+
+func (s sdk) ForceNewDeployment(ctx context.Context, cluster string, serviceArn string) error {
+	logrus.Debugf("Force new deployment for service %s", serviceArn)
+	_, err := s.ECS.UpdateServiceWithContext(ctx, &ecs.UpdateServiceInput{
+		Cluster:            aws.String(cluster),
+		ForceNewDeployment: aws.Bool(true),
+		Service:            aws.String(serviceArn),
+	})
+	return err
+}
+
+func (s sdk) DescribeServices(ctx context.Context, cluster string, serviceArns []string) ([]*ecs.Service, error) {
+	var services []*ecs.Service
+	// DescribeServices accepts up to 10 services
+	for i := 0; i < len(serviceArns); i += 10 {
+		end := i + 10
+		if end > len(serviceArns) {
+			end = len(serviceArns)
+		}
+		response, err := s.ECS.DescribeServicesWithContext(ctx, &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Include:  aws.StringSlice([]string{ecs.ServiceFieldTags}),
+			Services: aws.StringSlice(serviceArns[i:end]),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range response.Failures {
+			return nil, errors.Wrapf(api.ErrNotFound, "can't describe service %s: %s", aws.StringValue(f.Arn), aws.StringValue(f.Reason))
 		}
-		token = response.NextToken
+		services = append(services, response.Services...)
 	}
+	return services, nil
 }
 
-func (s sdk) GetTaskStoppedReason(ctx context.Context, cluster string, taskArn string) (string, error) {
-	taskDescriptions, err := s.ECS.DescribeTasksWithContext(ctx, &ecs.DescribeTasksInput{
-		Cluster: aws.String(cluster),
-		Tasks:   []*string{aws.String(taskArn)},
+func (s sdk) GetServiceMetrics(ctx context.Context, cluster string, service string) (serviceMetrics, error) {
+	dimensions := []*cloudwatch.Dimension{
+		{Name: aws.String("ClusterName"), Value: aws.String(cluster)},
+		{Name: aws.String("ServiceName"), Value: aws.String(service)},
+	}
+	query := func(id string, namespace string, name string) *cloudwatch.MetricDataQuery {
+		return &cloudwatch.MetricDataQuery{
+			Id: aws.String(id),
+			MetricStat: &cloudwatch.MetricStat{
+				Metric: &cloudwatch.Metric{
+					Dimensions: dimensions,
+					MetricName: aws.String(name),
+					Namespace:  aws.String(namespace),
+				},
+				Period: aws.Int64(60),
+				Stat:   aws.String(cloudwatch.StatisticAverage),
+			},
+		}
+	}
+
+	end := time.Now()
+	response, err := s.CWM.GetMetricDataWithContext(ctx, &cloudwatch.GetMetricDataInput{
+		StartTime: aws.Time(end.Add(-metricsPeriod)),
+		EndTime:   aws.Time(end),
+		MetricDataQueries: []*cloudwatch.MetricDataQuery{
+			query(metricCPUUtilized, containerInsightsNamespace, "CpuUtilized"),
+			query(metricCPUReserved, containerInsightsNamespace, "CpuReserved"),
+			query(metricMemoryUtilized, containerInsightsNamespace, "MemoryUtilized"),
+			query(metricMemoryReserved, containerInsightsNamespace, "MemoryReserved"),
+			query(metricCPUUtilization, ecsNamespace, "CPUUtilization"),
+			query(metricMemoryUtilization, ecsNamespace, "MemoryUtilization"),
+		},
+		ScanBy: aws.String(cloudwatch.ScanByTimestampDescending),
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if len(taskDescriptions.Tasks) == 0 {
-		return "", nil
+	metrics := serviceMetrics{}
+	for _, r := range response.MetricDataResults {
+		if len(r.Values) > 0 {
+			// latest datapoint comes first
+			metrics[aws.StringValue(r.Id)] = aws.Float64Value(r.Values[0])
+		}
 	}
-	task := taskDescriptions.Tasks[0]
-	return fmt.Sprintf(
-		"%s: %s",
-		aws.StringValue(task.StopCode),
-		aws.StringValue(task.StoppedReason)), nil
-
+	return metrics, nil
 }
 
 func (s sdk) DescribeStackEvents(ctx context.Context, stackID string) ([]*cloudformation.StackEvent, error) {
@@ -811,42 +1520,126 @@ func (s sdk) DeleteSecret(ctx context.Context, id string, recover bool) error {
 	return err
 }
 
-func (s sdk) GetLogs(ctx context.Context, name string, consumer func(container string, service string, message string), follow bool) error {
-	logGroup := fmt.Sprintf("/docker-compose/%s", name)
-	var startTime int64
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
-			var hasMore = true
-			var token *string
-			for hasMore {
-				events, err := s.CW.FilterLogEvents(&cloudwatchlogs.FilterLogEventsInput{
-					LogGroupName: aws.String(logGroup),
-					NextToken:    token,
-					StartTime:    aws.Int64(startTime),
-				})
-				if err != nil {
-					return err
-				}
-				if events.NextToken == nil {
-					hasMore = false
-				} else {
-					token = events.NextToken
-				}
+func (s sdk) DescribeLogStreams(ctx context.Context, group string, prefix string) ([]string, error) {
+	params := &cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName: aws.String(group),
+	}
+	if prefix != "" {
+		params.LogStreamNamePrefix = aws.String(prefix)
+	}
+	var streams []string
+	err := s.CW.DescribeLogStreamsPagesWithContext(ctx, params, func(page *cloudwatchlogs.DescribeLogStreamsOutput, lastPage bool) bool {
+		for _, stream := range page.LogStreams {
+			streams = append(streams, aws.StringValue(stream.LogStreamName))
+		}
+		return true
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+		return nil, errors.Wrapf(api.ErrNotFound, "log group %s", group)
+	}
+	return streams, err
+}
 
-				for _, event := range events.Events {
-					p := strings.Split(aws.StringValue(event.LogStreamName), "/")
-					consumer(p[1], p[2], aws.StringValue(event.Message))
-					startTime = *event.IngestionTime
-				}
-			}
+func (s sdk) GetLogStreamEvents(ctx context.Context, group string, stream string, query logStreamQuery) ([]logStreamEvent, *string, error) {
+	params := &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String(group),
+		LogStreamName: aws.String(stream),
+		NextToken:     query.Token,
+		StartFromHead: aws.Bool(!query.FromTail),
+	}
+	if query.Token == nil {
+		// time range only applies to the first page, next ones are selected by token
+		if !query.Since.IsZero() {
+			params.StartTime = aws.Int64(aws.TimeUnixMilli(query.Since))
 		}
-		if !follow {
-			return nil
+		if !query.Until.IsZero() {
+			params.EndTime = aws.Int64(aws.TimeUnixMilli(query.Until))
+		}
+	}
+	if query.Limit > 0 {
+		params.Limit = aws.Int64(int64(query.Limit))
+	}
+	response, err := s.CW.GetLogEventsWithContext(ctx, params)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+		return nil, query.Token, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	events := make([]logStreamEvent, len(response.Events))
+	for i, event := range response.Events {
+		events[i] = logStreamEvent{
+			Stream:        stream,
+			Message:       aws.StringValue(event.Message),
+			Timestamp:     aws.Int64Value(event.Timestamp),
+			IngestionTime: aws.Int64Value(event.IngestionTime),
+		}
+	}
+	return events, response.NextForwardToken, nil
+}
+
+func (s sdk) StartLogsQuery(ctx context.Context, group string, query string, start time.Time, end time.Time) (string, error) {
+	response, err := s.CW.StartQueryWithContext(ctx, &cloudwatchlogs.StartQueryInput{
+		LogGroupName: aws.String(group),
+		QueryString:  aws.String(query),
+		StartTime:    aws.Int64(start.Unix()),
+		EndTime:      aws.Int64(end.Unix()),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(response.QueryId), nil
+}
+
+func (s sdk) GetLogsQueryResults(ctx context.Context, id string) (string, []LogQueryRow, error) {
+	response, err := s.CW.GetQueryResultsWithContext(ctx, &cloudwatchlogs.GetQueryResultsInput{
+		QueryId: aws.String(id),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	rows := make([]LogQueryRow, len(response.Results))
+	for i, result := range response.Results {
+		for _, field := range result {
+			rows[i] = append(rows[i], LogQueryField{
+				Field: aws.StringValue(field.Field),
+				Value: aws.StringValue(field.Value),
+			})
+		}
+	}
+	return aws.StringValue(response.Status), rows, nil
+}
+
+func (s sdk) StopLogsQuery(ctx context.Context, id string) error {
+	_, err := s.CW.StopQueryWithContext(ctx, &cloudwatchlogs.StopQueryInput{
+		QueryId: aws.String(id),
+	})
+	return err
+}
+
+func (s sdk) GetLogEvents(ctx context.Context, group string, stream string, token *string) ([]string, *string, error) {
+	var messages []string
+	for {
+		response, err := s.CW.GetLogEventsWithContext(ctx, &cloudwatchlogs.GetLogEventsInput{
+			LogGroupName:  aws.String(group),
+			LogStreamName: aws.String(stream),
+			NextToken:     token,
+			StartFromHead: aws.Bool(true),
+		})
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+			// log stream is created once container starts
+			return nil, token, nil
+		}
+		if err != nil {
+			return nil, nil, err
 		}
-		time.Sleep(500 * time.Millisecond)
+		for _, event := range response.Events {
+			messages = append(messages, aws.StringValue(event.Message))
+		}
+		if aws.StringValue(token) == aws.StringValue(response.NextForwardToken) {
+			return messages, token, nil
+		}
+		token = response.NextForwardToken
 	}
 }
 
@@ -863,6 +1656,9 @@ func (s sdk) DescribeService(ctx context.Context, cluster string, arn string) (a
 	for _, f := range services.Failures {
 		return api.ServiceStatus{}, errors.Wrapf(api.ErrNotFound, "can't get service status %s: %s", aws.StringValue(f.Detail), aws.StringValue(f.Reason))
 	}
+	if len(services.Services) == 0 {
+		return api.ServiceStatus{}, errors.Wrapf(api.ErrNotFound, "service %s does not exist in cluster %s", arn, cluster)
+	}
 	service := services.Services[0]
 	var name string
 	for _, t := range service.Tags {
@@ -874,12 +1670,13 @@ func (s sdk) DescribeService(ctx context.Context, cluster string, arn string) (a
 		return api.ServiceStatus{}, fmt.Errorf("service %s doesn't have a %s tag", *service.ServiceArn, api.ServiceLabel)
 	}
 	targetGroupArns := []string{}
-	for _, lb := range service.LoadBalancers {
+	loadBalancers, _ := serviceNetwork(service)
+	for _, lb := range loadBalancers {
 		targetGroupArns = append(targetGroupArns, *lb.TargetGroupArn)
 	}
 	// getURLwithPortMapping makes 2 queries
 	// one to get the target groups and another for load balancers
-	loadBalancers, err := s.getURLWithPortMapping(ctx, targetGroupArns)
+	publishers, err := s.getURLWithPortMapping(ctx, targetGroupArns)
 	if err != nil {
 		return api.ServiceStatus{}, err
 	}
@@ -888,125 +1685,136 @@ func (s sdk) DescribeService(ctx context.Context, cluster string, arn string) (a
 		Name:       name,
 		Replicas:   int(aws.Int64Value(service.RunningCount)),
 		Desired:    int(aws.Int64Value(service.DesiredCount)),
-		Publishers: loadBalancers,
+		Publishers: publishers,
 	}, nil
 }
 
 func (s sdk) DescribeServiceTasks(ctx context.Context, cluster string, project string, service string) ([]api.ContainerSummary, error) {
 	var summary []api.ContainerSummary
-	familly := fmt.Sprintf("%s-%s", project, service)
-	var token *string
+	params := &ecs.ListTasksInput{
+		Cluster: aws.String(cluster),
+		Family:  aws.String(fmt.Sprintf("%s-%s", project, service)),
+	}
 	for {
-		list, err := s.ECS.ListTasks(&ecs.ListTasksInput{
-			Cluster:    aws.String(cluster),
-			Family:     aws.String(familly),
-			LaunchType: nil,
-			MaxResults: nil,
-			NextToken:  token,
-		})
-		if err != nil {
-			return nil, err
-		}
-
-		if len(list.TaskArns) == 0 {
-			break
-		}
-		tasks, err := s.ECS.DescribeTasksWithContext(ctx, &ecs.DescribeTasksInput{
-			Cluster: aws.String(cluster),
-			Include: aws.StringSlice([]string{"TAGS"}),
-			Tasks:   list.TaskArns,
-		})
+		list, err := s.ECS.ListTasksWithContext(ctx, params)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, t := range tasks.Tasks {
-			var project string
-			var service string
-			for _, tag := range t.Tags {
-				switch aws.StringValue(tag.Key) {
-				case api.ProjectLabel:
-					project = aws.StringValue(tag.Value)
-				case api.ServiceLabel:
-					service = aws.StringValue(tag.Value)
-				}
-			}
-
-			id, err := arn.Parse(aws.StringValue(t.TaskArn))
+		if len(list.TaskArns) > 0 {
+			tasks, err := s.ECS.DescribeTasksWithContext(ctx, &ecs.DescribeTasksInput{
+				Cluster: aws.String(cluster),
+				Include: aws.StringSlice([]string{"TAGS"}),
+				Tasks:   list.TaskArns,
+			})
 			if err != nil {
 				return nil, err
 			}
-
-			summary = append(summary, api.ContainerSummary{
-				ID:      id.String(),
-				Name:    id.Resource,
-				Project: project,
-				Service: service,
-				//nolint:staticcheck // Preserving for compatibility
-				State: strings.Title(strings.ToLower(aws.StringValue(t.LastStatus))),
-			})
+			for _, t := range tasks.Tasks {
+				task, err := taskSummary(t, time.Now())
+				if err != nil {
+					return nil, err
+				}
+				summary = append(summary, task)
+			}
 		}
 
-		if list.NextToken == token {
-			break
+		if list.NextToken == nil {
+			return summary, nil
 		}
-		token = list.NextToken
+		params.NextToken = list.NextToken
 	}
-
-	return summary, nil
 }
 
 func (s sdk) getURLWithPortMapping(ctx context.Context, targetGroupArns []string) ([]api.PortPublisher, error) {
-	if len(targetGroupArns) == 0 {
-		return nil, nil
-	}
-	groups, err := s.ELB.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{
-		TargetGroupArns: aws.StringSlice(targetGroupArns),
-	})
+	publishers, err := s.GetTargetGroupPublishers(ctx, targetGroupArns)
 	if err != nil {
 		return nil, err
 	}
-	lbarns := []*string{}
-	for _, tg := range groups.TargetGroups {
-		lbarns = append(lbarns, tg.LoadBalancerArns...)
+	loadBalancers := []api.PortPublisher{}
+	for _, arn := range targetGroupArns {
+		loadBalancers = append(loadBalancers, publishers[arn]...)
 	}
+	return loadBalancers, nil
+}
 
-	lbs, err := s.ELB.DescribeLoadBalancersWithContext(ctx, &elbv2.DescribeLoadBalancersInput{
-		LoadBalancerArns: lbarns,
-	})
+// elbv2DescribeBatchSize is the maximum number of ARNs elbv2 Describe* APIs accept
+const elbv2DescribeBatchSize = 20
 
+func (s sdk) DescribeTargetHealth(ctx context.Context, targetGroupArn string) ([]*elbv2.TargetHealthDescription, error) {
+	response, err := s.ELB.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(targetGroupArn),
+	})
 	if err != nil {
 		return nil, err
 	}
-	filterLB := func(arn *string, lbs []*elbv2.LoadBalancer) *elbv2.LoadBalancer {
-		if aws.StringValue(arn) == "" {
-			// load balancer arn is nil/""
-			return nil
+	return response.TargetHealthDescriptions, nil
+}
+
+func (s sdk) GetTargetGroupPublishers(ctx context.Context, targetGroupArns []string) (map[string][]api.PortPublisher, error) {
+	if len(targetGroupArns) == 0 {
+		return nil, nil
+	}
+	var groups []*elbv2.TargetGroup
+	for i := 0; i < len(targetGroupArns); i += elbv2DescribeBatchSize {
+		end := i + elbv2DescribeBatchSize
+		if end > len(targetGroupArns) {
+			end = len(targetGroupArns)
+		}
+		response, err := s.ELB.DescribeTargetGroupsWithContext(ctx, &elbv2.DescribeTargetGroupsInput{
+			TargetGroupArns: aws.StringSlice(targetGroupArns[i:end]),
+		})
+		if err != nil {
+			return nil, err
 		}
-		for _, lb := range lbs {
-			if aws.StringValue(lb.LoadBalancerArn) == aws.StringValue(arn) {
-				return lb
+		groups = append(groups, response.TargetGroups...)
+	}
+
+	// load balancers are shared by services, so we only describe each once
+	var lbarns []string
+	seen := map[string]bool{}
+	for _, tg := range groups {
+		for _, arn := range tg.LoadBalancerArns {
+			if a := aws.StringValue(arn); a != "" && !seen[a] {
+				seen[a] = true
+				lbarns = append(lbarns, a)
 			}
 		}
-		return nil
 	}
-	loadBalancers := []api.PortPublisher{}
-	for _, tg := range groups.TargetGroups {
+	lbs := map[string]*elbv2.LoadBalancer{}
+	for i := 0; i < len(lbarns); i += elbv2DescribeBatchSize {
+		end := i + elbv2DescribeBatchSize
+		if end > len(lbarns) {
+			end = len(lbarns)
+		}
+		response, err := s.ELB.DescribeLoadBalancersWithContext(ctx, &elbv2.DescribeLoadBalancersInput{
+			LoadBalancerArns: aws.StringSlice(lbarns[i:end]),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, lb := range response.LoadBalancers {
+			lbs[aws.StringValue(lb.LoadBalancerArn)] = lb
+		}
+	}
+
+	publishers := map[string][]api.PortPublisher{}
+	for _, tg := range groups {
 		for _, lbarn := range tg.LoadBalancerArns {
-			lb := filterLB(lbarn, lbs.LoadBalancers)
-			if lb == nil {
+			lb, ok := lbs[aws.StringValue(lbarn)]
+			if !ok {
 				continue
 			}
-			loadBalancers = append(loadBalancers, api.PortPublisher{
+			arn := aws.StringValue(tg.TargetGroupArn)
+			publishers[arn] = append(publishers[arn], api.PortPublisher{
 				URL:           fmt.Sprintf("%s:%d", aws.StringValue(lb.DNSName), aws.Int64Value(tg.Port)),
 				TargetPort:    int(aws.Int64Value(tg.Port)),
 				PublishedPort: int(aws.Int64Value(tg.Port)),
 				Protocol:      strings.ToLower(aws.StringValue(tg.Protocol)),
 			})
-
 		}
 	}
-	return loadBalancers, nil
+	return publishers, nil
 }
 
 func (s sdk) ListTasks(ctx context.Context, cluster string, family string) ([]string, error) {
@@ -1192,6 +2000,27 @@ func (s sdk) ListFileSystems(ctx context.Context, tags map[string]string) ([]aws
 	}
 }
 
+func (s sdk) ListProjectFileSystems(ctx context.Context, project string) ([]projectFileSystem, error) {
+	var results []projectFileSystem
+	err := s.EFS.DescribeFileSystemsPagesWithContext(ctx, &efs.DescribeFileSystemsInput{}, func(page *efs.DescribeFileSystemsOutput, lastPage bool) bool {
+		for _, filesystem := range page.FileSystems {
+			if !containsAll(filesystem.Tags, map[string]string{api.ProjectLabel: project}) {
+				continue
+			}
+			for _, t := range filesystem.Tags {
+				if aws.StringValue(t.Key) == api.VolumeLabel {
+					results = append(results, projectFileSystem{
+						Volume: aws.StringValue(t.Value),
+						ID:     aws.StringValue(filesystem.FileSystemId),
+					})
+				}
+			}
+		}
+		return true
+	})
+	return results, err
+}
+
 func containsAll(tags []*efs.Tag, required map[string]string) bool {
 TAGS:
 	for key, value := range required {
@@ -1254,3 +2083,258 @@ func (s sdk) DeleteFileSystem(ctx context.Context, id string) error {
 	})
 	return err
 }
+
+func (s sdk) ListMountTargets(ctx context.Context, filesystem string) ([]string, error) {
+	var ids []string
+	var marker *string
+	for {
+		desc, err := s.EFS.DescribeMountTargetsWithContext(ctx, &efs.DescribeMountTargetsInput{
+			FileSystemId: aws.String(filesystem),
+			Marker:       marker,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, mt := range desc.MountTargets {
+			ids = append(ids, aws.StringValue(mt.MountTargetId))
+		}
+		if desc.NextMarker == nil {
+			return ids, nil
+		}
+		marker = desc.NextMarker
+	}
+}
+
+func (s sdk) DeleteMountTarget(ctx context.Context, id string) error {
+	_, err := s.EFS.DeleteMountTargetWithContext(ctx, &efs.DeleteMountTargetInput{
+		MountTargetId: aws.String(id),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == efs.ErrCodeMountTargetNotFound {
+		return nil
+	}
+	return err
+}
+
+func (s sdk) ListAccessPoints(ctx context.Context, filesystem string) ([]string, error) {
+	var ids []string
+	var token *string
+	for {
+		desc, err := s.EFS.DescribeAccessPointsWithContext(ctx, &efs.DescribeAccessPointsInput{
+			FileSystemId: aws.String(filesystem),
+			NextToken:    token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ap := range desc.AccessPoints {
+			ids = append(ids, aws.StringValue(ap.AccessPointId))
+		}
+		if desc.NextToken == nil {
+			return ids, nil
+		}
+		token = desc.NextToken
+	}
+}
+
+func (s sdk) DeleteAccessPoint(ctx context.Context, id string) error {
+	_, err := s.EFS.DeleteAccessPointWithContext(ctx, &efs.DeleteAccessPointInput{
+		AccessPointId: aws.String(id),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == efs.ErrCodeAccessPointNotFound {
+		return nil
+	}
+	return err
+}
+
+func (s sdk) GetCallerIdentity(ctx context.Context) (callerIdentity, error) {
+	identity, err := s.STS.GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return callerIdentity{}, err
+	}
+	return callerIdentity{
+		Account: aws.StringValue(identity.Account),
+		ARN:     aws.StringValue(identity.Arn),
+	}, nil
+}
+
+// ListDeploymentRevisions returns the revisions stored for project in history bucket, oldest first. Only revision number, date and
+// digest are set, details are returned by DescribeDeploymentRevision.
+// History bucket name is predictable, so all calls check it is owned by the deploying account, not by someone who created it first.
+func (s sdk) ListDeploymentRevisions(ctx context.Context, bucket string, owner string, project string) ([]DeploymentRevision, error) {
+	var revisions []DeploymentRevision
+	err := s.S3.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket:              aws.String(bucket),
+		ExpectedBucketOwner: aws.String(owner),
+		Prefix:              aws.String(project + "/"),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, o := range page.Contents {
+			revision, ok := parseRevisionKey(project, aws.StringValue(o.Key))
+			if !ok {
+				continue
+			}
+			revisions = append(revisions, DeploymentRevision{
+				Revision: revision,
+				Deployed: aws.TimeValue(o.LastModified),
+				digest:   strings.Trim(aws.StringValue(o.ETag), `"`),
+			})
+		}
+		return true
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchBucket {
+		return nil, nil
+	}
+	return revisions, err
+}
+
+// DescribeDeploymentRevision returns revision with the details of its deployment
+func (s sdk) DescribeDeploymentRevision(ctx context.Context, bucket string, owner string, project string, revision int) (DeploymentRevision, error) {
+	object, err := s.S3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket:              aws.String(bucket),
+		ExpectedBucketOwner: aws.String(owner),
+		Key:                 aws.String(revisionKey(project, revision)),
+	})
+	if err != nil {
+		return DeploymentRevision{}, revisionError(err, fmt.Sprintf("revision %d of project %s", revision, project))
+	}
+	return toDeploymentRevision(revision, object.LastModified, object.Metadata), nil
+}
+
+// GetDeploymentRevision returns revision with the details of its deployment, and the template deployed
+func (s sdk) GetDeploymentRevision(ctx context.Context, bucket string, owner string, project string, revision int) (DeploymentRevision, []byte, error) {
+	deployment, template, err := s.getRevisionObject(ctx, bucket, owner, revisionKey(project, revision))
+	if err != nil {
+		return DeploymentRevision{}, nil, revisionError(err, fmt.Sprintf("revision %d of project %s", revision, project))
+	}
+	deployment.Revision = revision
+	return deployment, template, nil
+}
+
+// GetPendingDeploymentRevision returns the deployment of project which was not complete when it was stored, and its template
+func (s sdk) GetPendingDeploymentRevision(ctx context.Context, bucket string, owner string, project string) (DeploymentRevision, []byte, error) {
+	deployment, template, err := s.getRevisionObject(ctx, bucket, owner, pendingRevisionKey(project))
+	if err != nil {
+		return DeploymentRevision{}, nil, revisionError(err, "pending deployment of project "+project)
+	}
+	return deployment, template, nil
+}
+
+func (s sdk) getRevisionObject(ctx context.Context, bucket string, owner string, key string) (DeploymentRevision, []byte, error) {
+	object, err := s.S3.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket:              aws.String(bucket),
+		ExpectedBucketOwner: aws.String(owner),
+		Key:                 aws.String(key),
+	})
+	if err != nil {
+		return DeploymentRevision{}, nil, err
+	}
+	defer object.Body.Close() //nolint:errcheck
+	template, err := io.ReadAll(object.Body)
+	if err != nil {
+		return DeploymentRevision{}, nil, err
+	}
+	return toDeploymentRevision(0, object.LastModified, object.Metadata), template, nil
+}
+
+// PutDeploymentRevision stores template deployed for project as revision. History bucket is created on first deployment in account
+// and region
+func (s sdk) PutDeploymentRevision(ctx context.Context, bucket string, owner string, region string, project string, revision DeploymentRevision, template []byte) error {
+	return s.putRevisionObject(ctx, bucket, owner, region, revisionKey(project, revision.Revision), revision, template)
+}
+
+// PutPendingDeploymentRevision stores template of a deployment of project which is not complete yet, replacing the pending one if any
+func (s sdk) PutPendingDeploymentRevision(ctx context.Context, bucket string, owner string, region string, project string, revision DeploymentRevision, template []byte) error {
+	return s.putRevisionObject(ctx, bucket, owner, region, pendingRevisionKey(project), revision, template)
+}
+
+// DeletePendingDeploymentRevision deletes the pending deployment of project
+func (s sdk) DeletePendingDeploymentRevision(ctx context.Context, bucket string, owner string, project string) error {
+	_, err := s.S3.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket:              aws.String(bucket),
+		ExpectedBucketOwner: aws.String(owner),
+		Key:                 aws.String(pendingRevisionKey(project)),
+	})
+	return err
+}
+
+func (s sdk) putRevisionObject(ctx context.Context, bucket string, owner string, region string, key string, revision DeploymentRevision, template []byte) error {
+	put := func() error {
+		_, err := s.S3.PutObjectWithContext(ctx, &s3.PutObjectInput{
+			Bucket:              aws.String(bucket),
+			ExpectedBucketOwner: aws.String(owner),
+			Key:                 aws.String(key),
+			Body:                bytes.NewReader(template),
+			ContentType:         aws.String("application/x-yaml"),
+			Metadata: aws.StringMap(map[string]string{
+				revisionProjectHashMetadata: revision.ProjectHash,
+				revisionVersionMetadata:     revision.Version,
+				revisionUserMetadata:        revision.User,
+			}),
+		})
+		return err
+	}
+	err := put()
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != s3.ErrCodeNoSuchBucket {
+		return err
+	}
+	if err := s.createHistoryBucket(ctx, bucket, owner, region); err != nil {
+		return err
+	}
+	return put()
+}
+
+func (s sdk) createHistoryBucket(ctx context.Context, bucket string, owner string, region string) error {
+	logrus.Debugf("Create s3 bucket %q to store deployment history", bucket)
+	var configuration *s3.CreateBucketConfiguration
+	if region != "us-east-1" {
+		configuration = &s3.CreateBucketConfiguration{
+			LocationConstraint: aws.String(region),
+		}
+	}
+	_, err := s.S3.CreateBucketWithContext(ctx, &s3.CreateBucketInput{
+		Bucket:                    aws.String(bucket),
+		CreateBucketConfiguration: configuration,
+	})
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case s3.ErrCodeBucketAlreadyOwnedByYou:
+			return nil
+		case s3.ErrCodeBucketAlreadyExists:
+			return errors.Errorf("history bucket %s already exists and is owned by another account, refusing to store deployed templates", bucket)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	_, err = s.S3.PutPublicAccessBlockWithContext(ctx, &s3.PutPublicAccessBlockInput{
+		Bucket:              aws.String(bucket),
+		ExpectedBucketOwner: aws.String(owner),
+		PublicAccessBlockConfiguration: &s3.PublicAccessBlockConfiguration{
+			BlockPublicAcls:       aws.Bool(true),
+			BlockPublicPolicy:     aws.Bool(true),
+			IgnorePublicAcls:      aws.Bool(true),
+			RestrictPublicBuckets: aws.Bool(true),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	// a revision overwritten by a concurrent deployment can still be recovered
+	_, err = s.S3.PutBucketVersioningWithContext(ctx, &s3.PutBucketVersioningInput{
+		Bucket:              aws.String(bucket),
+		ExpectedBucketOwner: aws.String(owner),
+		VersioningConfiguration: &s3.VersioningConfiguration{
+			Status: aws.String(s3.BucketVersioningStatusEnabled),
+		},
+	})
+	return err
+}
+
+func revisionError(err error, revision string) error {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case s3.ErrCodeNoSuchBucket, s3.ErrCodeNoSuchKey, "NotFound":
+			return errors.Wrap(api.ErrNotFound, revision)
+		}
+	}
+	return err
+}
@@ -0,0 +1,491 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-uuid"
+	"github.com/moby/term"
+	"github.com/sirupsen/logrus"
+)
+
+// ECS Exec relies on the SSM Session Manager data channel. This is a minimal Go client for this
+// protocol, so we don't depend on users installing the session-manager-plugin.
+// see https://github.com/aws/session-manager-plugin/tree/mainline/src/message
+
+const (
+	// sessionClientVersion is announced to the SSM agent, which enables features based on client version
+	sessionClientVersion = "1.2.463.0"
+
+	messageInputStreamData  = "input_stream_data"
+	messageOutputStreamData = "output_stream_data"
+	messageAcknowledge      = "acknowledge"
+	messageChannelClosed    = "channel_closed"
+
+	payloadOutput               uint32 = 1
+	payloadSize                 uint32 = 3
+	payloadHandshakeRequest     uint32 = 5
+	payloadHandshakeResponse    uint32 = 6
+	payloadHandshakeComplete    uint32 = 7
+	payloadEncChallengeRequest  uint32 = 8
+	payloadStdErr               uint32 = 11
+	payloadExitCode             uint32 = 12
+	sessionMessageHeaderLength         = 116
+	sessionMessageTypeLength           = 32
+	sessionMessageSchemaVersion uint32 = 1
+
+	actionStatusSuccess = 1
+	actionStatusFailed  = 2
+	actionKMSEncryption = "KMSEncryption"
+
+	sessionPingInterval = 5 * time.Minute
+	sessionInputBuffer  = 1024
+	// endOfTransmission makes the remote terminal close standard input of the command
+	endOfTransmission = "\x04"
+)
+
+var errEncryptedSession = fmt.Errorf("encrypted sessions are not supported, disable KMS encryption for ECS Exec on the cluster")
+
+// sessionMessage is the binary frame exchanged over the data channel
+type sessionMessage struct {
+	MessageType    string
+	SchemaVersion  uint32
+	CreatedDate    uint64
+	SequenceNumber int64
+	Flags          uint64
+	MessageID      []byte
+	PayloadType    uint32
+	Payload        []byte
+}
+
+func (m sessionMessage) marshal() []byte {
+	buf := make([]byte, sessionMessageHeaderLength+4+len(m.Payload))
+	binary.BigEndian.PutUint32(buf[0:], sessionMessageHeaderLength)
+	copy(buf[4:36], []byte(fmt.Sprintf("%-*s", sessionMessageTypeLength, m.MessageType)))
+	binary.BigEndian.PutUint32(buf[36:], m.SchemaVersion)
+	binary.BigEndian.PutUint64(buf[40:], m.CreatedDate)
+	binary.BigEndian.PutUint64(buf[48:], uint64(m.SequenceNumber))
+	binary.BigEndian.PutUint64(buf[56:], m.Flags)
+	// message ID is serialized with least significant bits first
+	copy(buf[64:72], m.MessageID[8:16])
+	copy(buf[72:80], m.MessageID[0:8])
+	digest := sha256.Sum256(m.Payload)
+	copy(buf[80:112], digest[:])
+	binary.BigEndian.PutUint32(buf[112:], m.PayloadType)
+	binary.BigEndian.PutUint32(buf[116:], uint32(len(m.Payload)))
+	copy(buf[120:], m.Payload)
+	return buf
+}
+
+func unmarshalSessionMessage(buf []byte) (sessionMessage, error) {
+	if len(buf) < sessionMessageHeaderLength+4 {
+		return sessionMessage{}, fmt.Errorf("invalid session message: %d bytes is shorter than message header", len(buf))
+	}
+	headerLength := binary.BigEndian.Uint32(buf[0:])
+	if int(headerLength)+4 > len(buf) {
+		return sessionMessage{}, fmt.Errorf("invalid session message: header length %d exceeds message size", headerLength)
+	}
+	id := make([]byte, 16)
+	copy(id[0:8], buf[72:80])
+	copy(id[8:16], buf[64:72])
+	m := sessionMessage{
+		MessageType:    strings.TrimRight(string(buf[4:36]), " \x00"),
+		SchemaVersion:  binary.BigEndian.Uint32(buf[36:]),
+		CreatedDate:    binary.BigEndian.Uint64(buf[40:]),
+		SequenceNumber: int64(binary.BigEndian.Uint64(buf[48:])),
+		Flags:          binary.BigEndian.Uint64(buf[56:]),
+		MessageID:      id,
+		PayloadType:    binary.BigEndian.Uint32(buf[112:]),
+	}
+	length := binary.BigEndian.Uint32(buf[headerLength:])
+	start := int(headerLength) + 4
+	if start+int(length) > len(buf) {
+		return sessionMessage{}, fmt.Errorf("invalid session message: payload length %d exceeds message size", length)
+	}
+	m.Payload = buf[start : start+int(length)]
+	digest := sha256.Sum256(m.Payload)
+	if !bytes.Equal(digest[:], buf[80:112]) {
+		return sessionMessage{}, fmt.Errorf("invalid session message %d: payload digest mismatch", m.SequenceNumber)
+	}
+	return m, nil
+}
+
+type openDataChannelInput struct {
+	MessageSchemaVersion string
+	RequestID            string `json:"RequestId"`
+	TokenValue           string
+	ClientID             string `json:"ClientId"`
+	ClientVersion        string
+}
+
+type acknowledgeContent struct {
+	AcknowledgedMessageType           string
+	AcknowledgedMessageID             string `json:"AcknowledgedMessageId"`
+	AcknowledgedMessageSequenceNumber int64
+	IsSequentialMessage               bool
+}
+
+type handshakeRequest struct {
+	AgentVersion           string
+	RequestedClientActions []struct {
+		ActionType       string
+		ActionParameters json.RawMessage
+	}
+}
+
+type processedClientAction struct {
+	ActionType   string
+	ActionStatus int
+	Error        string `json:",omitempty"`
+}
+
+type handshakeResponse struct {
+	ClientVersion          string
+	ProcessedClientActions []processedClientAction
+	Errors                 []string
+}
+
+type channelClosed struct {
+	SessionID string `json:"SessionId"`
+	Output    string
+}
+
+type terminalSize struct {
+	Cols uint32 `json:"cols"`
+	Rows uint32 `json:"rows"`
+}
+
+// sessionClient streams a Session Manager data channel to local stdin/stdout
+type sessionClient struct {
+	conn      *websocket.Conn
+	writeLock sync.Mutex
+	sequence  int64
+	expected  int64
+	pending   map[int64]sessionMessage
+	ready     bool
+	exitCode  int
+}
+
+func openSession(ctx context.Context, streamURL string, token string) (*sessionClient, error) {
+	requestID, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	clientID, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	open, err := json.Marshal(openDataChannelInput{
+		MessageSchemaVersion: "1.0",
+		RequestID:            requestID,
+		TokenValue:           token,
+		ClientID:             clientID,
+		ClientVersion:        sessionClientVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, streamURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session data channel: %w", err)
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, open); err != nil {
+		conn.Close() //nolint:errcheck
+		return nil, err
+	}
+	return &sessionClient{
+		conn:    conn,
+		pending: map[int64]sessionMessage{},
+	}, nil
+}
+
+// Close terminates the data channel
+func (c *sessionClient) Close() error {
+	return c.conn.Close()
+}
+
+// Run forwards stdin to the remote process and its output to stdout/stderr until the session is closed
+// by the agent. Terminal sizes received from resize are sent as the remote pseudo terminal size.
+func (c *sessionClient) Run(ctx context.Context, stdin io.Reader, stdout io.Writer, stderr io.Writer, resize <-chan terminalSize) (int, error) {
+	done := make(chan struct{})
+	defer close(done)
+	incoming := make(chan sessionMessage)
+	failed := make(chan error, 1)
+	go func() {
+		for {
+			_, data, err := c.conn.ReadMessage()
+			if err != nil {
+				failed <- err
+				return
+			}
+			msg, err := unmarshalSessionMessage(data)
+			if err != nil {
+				failed <- err
+				return
+			}
+			select {
+			case incoming <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	input := make(chan []byte)
+	var size *terminalSize
+	ping := time.NewTicker(sessionPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case err := <-failed:
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return c.exitCode, nil
+			}
+			return 0, err
+		case <-ping.C:
+			c.writeLock.Lock()
+			err := c.conn.WriteControl(websocket.PingMessage, []byte("keepalive"), time.Now().Add(time.Minute))
+			c.writeLock.Unlock()
+			if err != nil {
+				return 0, err
+			}
+		case s := <-resize:
+			size = &s
+			if c.ready {
+				if err := c.sendSize(s); err != nil {
+					return 0, err
+				}
+			}
+		case data, ok := <-input:
+			if !ok {
+				input = nil
+				continue
+			}
+			if err := c.send(payloadOutput, data); err != nil {
+				return 0, err
+			}
+		case msg := <-incoming:
+			switch msg.MessageType {
+			case messageChannelClosed:
+				var closed channelClosed
+				if err := json.Unmarshal(msg.Payload, &closed); err == nil && closed.Output != "" {
+					fmt.Fprintln(stderr, closed.Output)
+				}
+				return c.exitCode, nil
+			case messageOutputStreamData:
+				if err := c.acknowledge(msg); err != nil {
+					return 0, err
+				}
+				completed, err := c.receive(msg, stdout, stderr)
+				if err != nil {
+					return 0, err
+				}
+				if completed && !c.ready {
+					c.ready = true
+					if size != nil {
+						if err := c.sendSize(*size); err != nil {
+							return 0, err
+						}
+					}
+					go c.readInput(stdin, input, done)
+				}
+			case messageAcknowledge:
+				// the data channel is a websocket over TCP, we don't have to retransmit unacknowledged input
+			default:
+				logrus.Debugf("ignoring session message %q", msg.MessageType)
+			}
+		}
+	}
+}
+
+// receive processes output messages in sequence order, buffering those received ahead of time.
+// It returns true once the session handshake completed.
+func (c *sessionClient) receive(msg sessionMessage, stdout io.Writer, stderr io.Writer) (bool, error) {
+	if msg.SequenceNumber < c.expected {
+		// already processed, agent is resending as it didn't get our acknowledgement
+		return false, nil
+	}
+	c.pending[msg.SequenceNumber] = msg
+	completed := false
+	for {
+		next, ok := c.pending[c.expected]
+		if !ok {
+			return completed, nil
+		}
+		delete(c.pending, c.expected)
+		c.expected++
+		switch next.PayloadType {
+		case payloadOutput:
+			if _, err := stdout.Write(next.Payload); err != nil {
+				return completed, err
+			}
+		case payloadStdErr:
+			if _, err := stderr.Write(next.Payload); err != nil {
+				return completed, err
+			}
+		case payloadExitCode:
+			code, err := strconv.Atoi(strings.TrimSpace(string(next.Payload)))
+			if err == nil {
+				c.exitCode = code
+			}
+		case payloadHandshakeRequest:
+			if err := c.handshake(next.Payload); err != nil {
+				return completed, err
+			}
+		case payloadHandshakeComplete:
+			completed = true
+		case payloadEncChallengeRequest:
+			return completed, errEncryptedSession
+		default:
+			logrus.Debugf("ignoring session payload type %d", next.PayloadType)
+		}
+	}
+}
+
+func (c *sessionClient) handshake(payload []byte) error {
+	var request handshakeRequest
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return fmt.Errorf("invalid session handshake request: %w", err)
+	}
+	response := handshakeResponse{
+		ClientVersion:          sessionClientVersion,
+		ProcessedClientActions: []processedClientAction{},
+		Errors:                 []string{},
+	}
+	var unsupported error
+	for _, action := range request.RequestedClientActions {
+		processed := processedClientAction{
+			ActionType:   action.ActionType,
+			ActionStatus: actionStatusSuccess,
+		}
+		if action.ActionType == actionKMSEncryption {
+			processed.ActionStatus = actionStatusFailed
+			processed.Error = "KMS encryption is not supported by this client"
+			unsupported = errEncryptedSession
+		}
+		response.ProcessedClientActions = append(response.ProcessedClientActions, processed)
+	}
+	b, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	if err := c.send(payloadHandshakeResponse, b); err != nil {
+		return err
+	}
+	return unsupported
+}
+
+func (c *sessionClient) readInput(stdin io.Reader, input chan<- []byte, done <-chan struct{}) {
+	defer close(input)
+	if stdin == nil {
+		return
+	}
+	// remote pseudo terminal only ends input on end-of-transmission, which a local terminal sends on Ctrl-D
+	_, isTerminal := term.GetFdInfo(stdin)
+	lineStart := true
+	for {
+		buf := make([]byte, sessionInputBuffer)
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			lineStart = buf[n-1] == '\n'
+			select {
+			case input <- buf[:n]:
+			case <-done:
+				return
+			}
+		}
+		if err != nil {
+			if err == io.EOF && !isTerminal {
+				eot := endOfTransmission
+				if !lineStart {
+					// the first one only sends the pending line
+					eot += endOfTransmission
+				}
+				select {
+				case input <- []byte(eot):
+				case <-done:
+				}
+			}
+			return
+		}
+	}
+}
+
+func (c *sessionClient) sendSize(size terminalSize) error {
+	b, err := json.Marshal(size)
+	if err != nil {
+		return err
+	}
+	return c.send(payloadSize, b)
+}
+
+func (c *sessionClient) send(payloadType uint32, payload []byte) error {
+	err := c.write(messageInputStreamData, c.sequence, 0, payloadType, payload)
+	c.sequence++
+	return err
+}
+
+func (c *sessionClient) acknowledge(msg sessionMessage) error {
+	id, err := uuid.FormatUUID(msg.MessageID)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(acknowledgeContent{
+		AcknowledgedMessageType:           msg.MessageType,
+		AcknowledgedMessageID:             id,
+		AcknowledgedMessageSequenceNumber: msg.SequenceNumber,
+		IsSequentialMessage:               true,
+	})
+	if err != nil {
+		return err
+	}
+	return c.write(messageAcknowledge, 0, 3, 0, b)
+}
+
+func (c *sessionClient) write(messageType string, sequence int64, flags uint64, payloadType uint32, payload []byte) error {
+	id, err := uuid.GenerateRandomBytes(16)
+	if err != nil {
+		return err
+	}
+	msg := sessionMessage{
+		MessageType:    messageType,
+		SchemaVersion:  sessionMessageSchemaVersion,
+		CreatedDate:    uint64(time.Now().UnixNano() / int64(time.Millisecond)),
+		SequenceNumber: sequence,
+		Flags:          flags,
+		MessageID:      id,
+		PayloadType:    payloadType,
+		Payload:        payload,
+	}
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	return c.conn.WriteMessage(websocket.BinaryMessage, msg.marshal())
+}
@@ -0,0 +1,191 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-uuid"
+	"gotest.tools/v3/assert"
+)
+
+// agentStandIn plays the SSM agent side of a session data channel
+type agentStandIn struct {
+	t        *testing.T
+	conn     *websocket.Conn
+	received []sessionMessage
+}
+
+func (a *agentStandIn) send(messageType string, sequence int64, payloadType uint32, payload string) {
+	id, err := uuid.GenerateRandomBytes(16)
+	assert.Check(a.t, err)
+	msg := sessionMessage{
+		MessageType:    messageType,
+		SchemaVersion:  sessionMessageSchemaVersion,
+		SequenceNumber: sequence,
+		MessageID:      id,
+		PayloadType:    payloadType,
+		Payload:        []byte(payload),
+	}
+	assert.Check(a.t, a.conn.WriteMessage(websocket.BinaryMessage, msg.marshal()))
+}
+
+// receive returns the next message of the given type sent by client
+func (a *agentStandIn) receive(messageType string) sessionMessage {
+	for {
+		for i, msg := range a.received {
+			if msg.MessageType == messageType {
+				a.received = append(a.received[:i], a.received[i+1:]...)
+				return msg
+			}
+		}
+		_, data, err := a.conn.ReadMessage()
+		if !assert.Check(a.t, err) {
+			return sessionMessage{}
+		}
+		msg, err := unmarshalSessionMessage(data)
+		assert.Check(a.t, err)
+		a.received = append(a.received, msg)
+	}
+}
+
+// acknowledged checks client did acknowledge messages with sequence numbers, in order
+func (a *agentStandIn) acknowledged(sequences ...int64) {
+	for _, sequence := range sequences {
+		var ack acknowledgeContent
+		assert.Check(a.t, json.Unmarshal(a.receive(messageAcknowledge).Payload, &ack))
+		assert.Equal(a.t, ack.AcknowledgedMessageSequenceNumber, sequence)
+	}
+}
+
+func startAgentStandIn(t *testing.T, agent func(a *agentStandIn)) string {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		assert.Check(t, err)
+		defer conn.Close() //nolint:errcheck
+
+		_, data, err := conn.ReadMessage()
+		assert.Check(t, err)
+		var open openDataChannelInput
+		assert.Check(t, json.Unmarshal(data, &open))
+		assert.Equal(t, open.TokenValue, "token")
+		agent(&agentStandIn{t: t, conn: conn})
+	}))
+	t.Cleanup(server.Close)
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestSessionMessageRoundTrip(t *testing.T) {
+	id, err := uuid.GenerateRandomBytes(16)
+	assert.NilError(t, err)
+	msg := sessionMessage{
+		MessageType:    messageOutputStreamData,
+		SchemaVersion:  sessionMessageSchemaVersion,
+		CreatedDate:    1234,
+		SequenceNumber: 42,
+		Flags:          3,
+		MessageID:      id,
+		PayloadType:    payloadOutput,
+		Payload:        []byte("hello"),
+	}
+	data := msg.marshal()
+	assert.Equal(t, len(data), sessionMessageHeaderLength+4+5)
+	assert.Equal(t, string(data[4:4+len(messageOutputStreamData)]), messageOutputStreamData)
+
+	decoded, err := unmarshalSessionMessage(data)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, decoded, msg)
+
+	data[len(data)-1] = 'X'
+	_, err = unmarshalSessionMessage(data)
+	assert.ErrorContains(t, err, "payload digest mismatch")
+}
+
+func TestSessionStreams(t *testing.T) {
+	url := startAgentStandIn(t, func(a *agentStandIn) {
+		a.send(messageOutputStreamData, 0, payloadHandshakeRequest, `{"AgentVersion":"3.1.0","RequestedClientActions":[{"ActionType":"SessionType","ActionParameters":{"SessionType":"Standard_Stream"}}]}`)
+		a.acknowledged(0)
+		response := a.receive(messageInputStreamData)
+		assert.Equal(t, response.PayloadType, payloadHandshakeResponse)
+		var handshake handshakeResponse
+		assert.Check(t, json.Unmarshal(response.Payload, &handshake))
+		assert.Equal(t, handshake.ProcessedClientActions[0].ActionStatus, actionStatusSuccess)
+		a.send(messageOutputStreamData, 1, payloadHandshakeComplete, `{}`)
+
+		a.acknowledged(1)
+		size := a.receive(messageInputStreamData)
+		assert.Equal(t, size.PayloadType, payloadSize)
+		assert.Equal(t, string(size.Payload), `{"cols":80,"rows":24}`)
+
+		// out of order and duplicated messages
+		a.send(messageOutputStreamData, 3, payloadOutput, "world")
+		a.send(messageOutputStreamData, 2, payloadOutput, "hello ")
+		a.send(messageOutputStreamData, 2, payloadOutput, "hello ")
+
+		a.acknowledged(3, 2, 2)
+		input := a.receive(messageInputStreamData)
+		assert.Equal(t, input.MessageType, messageInputStreamData)
+		assert.Equal(t, input.PayloadType, payloadOutput)
+		assert.Equal(t, string(input.Payload), "ls\n")
+		eot := a.receive(messageInputStreamData)
+		assert.Equal(t, string(eot.Payload), endOfTransmission)
+
+		a.send(messageChannelClosed, 0, 0, `{"SessionId":"123","Output":""}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	session, err := openSession(ctx, url, "token")
+	assert.NilError(t, err)
+	defer session.Close() //nolint:errcheck
+
+	resize := make(chan terminalSize, 1)
+	resize <- terminalSize{Cols: 80, Rows: 24}
+	var stdout bytes.Buffer
+	exitCode, err := session.Run(ctx, strings.NewReader("ls\n"), &stdout, &stdout, resize)
+	assert.NilError(t, err)
+	assert.Equal(t, exitCode, 0)
+	assert.Equal(t, stdout.String(), "hello world")
+}
+
+func TestSessionRejectsEncryption(t *testing.T) {
+	url := startAgentStandIn(t, func(a *agentStandIn) {
+		a.send(messageOutputStreamData, 0, payloadHandshakeRequest, `{"AgentVersion":"3.1.0","RequestedClientActions":[{"ActionType":"KMSEncryption","ActionParameters":{"KMSKeyId":"123"}}]}`)
+		response := a.receive(messageInputStreamData)
+		var handshake handshakeResponse
+		assert.Check(t, json.Unmarshal(response.Payload, &handshake))
+		assert.Equal(t, handshake.ProcessedClientActions[0].ActionStatus, actionStatusFailed)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	session, err := openSession(ctx, url, "token")
+	assert.NilError(t, err)
+	defer session.Close() //nolint:errcheck
+
+	_, err = session.Run(ctx, nil, &bytes.Buffer{}, &bytes.Buffer{}, nil)
+	assert.Equal(t, err, errEncryptedSession)
+}
@@ -0,0 +1,99 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+)
+
+// Start restores desired count and auto scaling configuration of services scaled down by Stop
+func (b *ComposeECS) Start(ctx context.Context, project *types.Project, options api.StartOptions) error {
+	return progress.Run(ctx, func(ctx context.Context) error {
+		return b.start(ctx, project.Name, options.Services)
+	})
+}
+
+func (b *ComposeECS) start(ctx context.Context, project string, services []string) error {
+	cluster, err := b.aws.GetStackClusterID(ctx, project)
+	if err != nil {
+		return err
+	}
+	resources, err := b.aws.ListStackResources(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	w := progress.ContextWriter(ctx)
+	var started stackResources
+	err = selectServices(resources, services).apply(awsTypeService, func(r stackResource) error {
+		tags, err := b.aws.GetServiceTags(ctx, r.ARN)
+		if err != nil {
+			return err
+		}
+		count, ok := tags[desiredCountTag]
+		if !ok {
+			// service is not stopped
+			return nil
+		}
+
+		w.Event(progress.StartingEvent(r.LogicalID))
+		desired, err := strconv.Atoi(count)
+		if err != nil {
+			return fmt.Errorf("invalid %s tag on service %s: %w", desiredCountTag, r.LogicalID, err)
+		}
+		if err := b.aws.ScaleService(ctx, cluster, r.ARN, desired); err != nil {
+			return err
+		}
+		keys := []string{desiredCountTag}
+		if v, ok := tags[minCapacityTag]; ok {
+			minCapacity, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s tag on service %s: %w", minCapacityTag, r.LogicalID, err)
+			}
+			if err := b.aws.UpdateServiceScalableTarget(ctx, r.ARN, minCapacity, false); err != nil {
+				return err
+			}
+			keys = append(keys, minCapacityTag)
+		}
+		if err := b.aws.UntagService(ctx, r.ARN, keys); err != nil {
+			return err
+		}
+		started = append(started, r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var arns []string
+	for _, r := range started {
+		arns = append(arns, r.ARN)
+	}
+	if err := b.aws.WaitServicesStable(ctx, cluster, arns); err != nil {
+		return err
+	}
+	for _, r := range started {
+		w.Event(progress.StartedEvent(r.LogicalID))
+	}
+	return nil
+}
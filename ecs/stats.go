@@ -0,0 +1,193 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/go-units"
+)
+
+const (
+	containerInsightsNamespace = "ECS/ContainerInsights"
+	ecsNamespace               = "AWS/ECS"
+	// metricsPeriod is how far back we look for datapoints, as metrics are published every minute
+	metricsPeriod = 5 * time.Minute
+
+	metricCPUUtilized       = "cpuUtilized"
+	metricCPUReserved       = "cpuReserved"
+	metricMemoryUtilized    = "memoryUtilized"
+	metricMemoryReserved    = "memoryReserved"
+	metricCPUUtilization    = "cpuUtilization"
+	metricMemoryUtilization = "memoryUtilization"
+)
+
+// serviceMetrics are the latest CloudWatch datapoints for a service, by metric query ID
+type serviceMetrics map[string]float64
+
+// ServiceStats is the resources reservation and utilization of a service
+type ServiceStats struct {
+	Service string
+	Running int
+	Desired int
+	// CPUReserved is the CPU units reserved by running tasks, 1024 units being one vCPU
+	CPUReserved float64
+	CPUUtilized float64
+	// MemoryReserved is the memory reserved by running tasks, in MiB
+	MemoryReserved float64
+	MemoryUtilized float64
+	Tasks          []TaskStats
+}
+
+// TaskStats is the resources reservation of a task
+type TaskStats struct {
+	ID        string
+	Status    string
+	CPU       float64
+	Memory    float64
+	StartedAt time.Time
+}
+
+// CPUPercent is the utilization of reserved CPU
+func (s ServiceStats) CPUPercent() float64 {
+	if s.CPUReserved == 0 {
+		return 0
+	}
+	return s.CPUUtilized / s.CPUReserved * 100
+}
+
+// MemoryPercent is the utilization of reserved memory
+func (s ServiceStats) MemoryPercent() float64 {
+	if s.MemoryReserved == 0 {
+		return 0
+	}
+	return s.MemoryUtilized / s.MemoryReserved * 100
+}
+
+// Stats collects resources usage for services from CloudWatch. Container Insights metrics are used when enabled on cluster,
+// otherwise utilization is computed from AWS/ECS metrics and tasks reservation.
+func (b *ComposeECS) Stats(ctx context.Context, project string, services []string) ([]ServiceStats, error) {
+	cluster, err := b.aws.GetStackClusterID(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := b.aws.ListStackResources(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	var arns []string
+	for _, r := range selectServices(resources, services) {
+		if r.Type == awsTypeService && r.ARN != "" {
+			arns = append(arns, r.ARN)
+		}
+	}
+	described, err := b.aws.DescribeServices(ctx, cluster, arns)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []ServiceStats
+	for _, service := range described {
+		s, err := b.serviceStats(ctx, cluster, service)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Service < stats[j].Service
+	})
+	return stats, nil
+}
+
+func (b *ComposeECS) serviceStats(ctx context.Context, cluster string, service *ecs.Service) (ServiceStats, error) {
+	stats := ServiceStats{
+		Service: serviceName(service),
+		Running: int(aws.Int64Value(service.RunningCount)),
+		Desired: int(aws.Int64Value(service.DesiredCount)),
+	}
+	tasks, err := b.aws.GetServiceTasks(ctx, cluster, aws.StringValue(service.ServiceArn), false)
+	if err != nil {
+		return stats, err
+	}
+	var cpu, memory float64
+	for _, t := range tasks {
+		task := TaskStats{
+			ID:        taskID(aws.StringValue(t.TaskArn)),
+			Status:    aws.StringValue(t.LastStatus),
+			CPU:       parseReservation(t.Cpu),
+			Memory:    parseReservation(t.Memory),
+			StartedAt: aws.TimeValue(t.StartedAt),
+		}
+		if task.Status == ecs.DesiredStatusRunning {
+			cpu += task.CPU
+			memory += task.Memory
+		}
+		stats.Tasks = append(stats.Tasks, task)
+	}
+	sort.Slice(stats.Tasks, func(i, j int) bool {
+		return stats.Tasks[i].ID < stats.Tasks[j].ID
+	})
+
+	// CloudWatch metrics dimension is the cluster name
+	clusterArn := aws.StringValue(service.ClusterArn)
+	metrics, err := b.aws.GetServiceMetrics(ctx, clusterArn[strings.LastIndex(clusterArn, "/")+1:], aws.StringValue(service.ServiceName))
+	if err != nil {
+		return stats, err
+	}
+	if reserved, ok := metrics[metricCPUReserved]; ok {
+		stats.CPUReserved = reserved
+		stats.CPUUtilized = metrics[metricCPUUtilized]
+	} else {
+		stats.CPUReserved = cpu
+		stats.CPUUtilized = metrics[metricCPUUtilization] * cpu / 100
+	}
+	if reserved, ok := metrics[metricMemoryReserved]; ok {
+		stats.MemoryReserved = reserved
+		stats.MemoryUtilized = metrics[metricMemoryUtilized]
+	} else {
+		stats.MemoryReserved = memory
+		stats.MemoryUtilized = metrics[metricMemoryUtilization] * memory / 100
+	}
+	return stats, nil
+}
+
+// parseReservation parses task CPU units or memory MiB, which are set as strings on ECS tasks
+func parseReservation(s *string) float64 {
+	v, err := strconv.ParseFloat(aws.StringValue(s), 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// FormatCPU formats CPU units as vCPU
+func FormatCPU(units float64) string {
+	return fmt.Sprintf("%.2f vCPU", units/1024)
+}
+
+// FormatMemory formats memory in MiB
+func FormatMemory(mib float64) string {
+	return units.BytesSize(mib * units.MiB)
+}
@@ -0,0 +1,93 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func expectServiceStats(m *MockAPIMockRecorder, project string, metrics serviceMetrics) {
+	m.GetStackClusterID(gomock.Any(), project).Return("cluster", nil)
+	m.ListStackResources(gomock.Any(), project).Return(stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:foo"},
+	}, nil)
+	m.DescribeServices(gomock.Any(), "cluster", []string{"arn:foo"}).Return([]*ecs.Service{
+		{
+			ClusterArn:   aws.String("arn:aws:ecs:eu-west-3:123456789012:cluster/cluster"),
+			ServiceArn:   aws.String("arn:foo"),
+			ServiceName:  aws.String("foo-service"),
+			RunningCount: aws.Int64(2),
+			DesiredCount: aws.Int64(2),
+			Tags:         []*ecs.Tag{{Key: aws.String(api.ServiceLabel), Value: aws.String("foo")}},
+		},
+	}, nil)
+	m.GetServiceTasks(gomock.Any(), "cluster", "arn:foo", false).Return([]*ecs.Task{
+		{TaskArn: aws.String("arn:aws:ecs:eu-west-3:123456789012:task/cluster/222"), LastStatus: aws.String("RUNNING"), Cpu: aws.String("256"), Memory: aws.String("512")},
+		{TaskArn: aws.String("arn:aws:ecs:eu-west-3:123456789012:task/cluster/111"), LastStatus: aws.String("RUNNING"), Cpu: aws.String("256"), Memory: aws.String("512")},
+	}, nil)
+	m.GetServiceMetrics(gomock.Any(), "cluster", "foo-service").Return(metrics, nil)
+}
+
+func TestStatsFromContainerInsights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectServiceStats(m.EXPECT(), t.Name(), serviceMetrics{
+		metricCPUReserved:       512,
+		metricCPUUtilized:       128,
+		metricMemoryReserved:    1024,
+		metricMemoryUtilized:    256,
+		metricCPUUtilization:    30,
+		metricMemoryUtilization: 30,
+	})
+
+	backend := &ComposeECS{aws: m}
+	stats, err := backend.Stats(context.TODO(), t.Name(), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(stats), 1)
+	assert.Equal(t, stats[0].Service, "foo")
+	assert.Equal(t, stats[0].CPUPercent(), 25.0)
+	assert.Equal(t, stats[0].MemoryPercent(), 25.0)
+	assert.Equal(t, stats[0].Tasks[0].ID, "111")
+}
+
+func TestStatsFromServiceMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectServiceStats(m.EXPECT(), t.Name(), serviceMetrics{
+		metricCPUUtilization:    50,
+		metricMemoryUtilization: 10,
+	})
+
+	backend := &ComposeECS{aws: m}
+	summary, err := backend.Top(context.TODO(), t.Name(), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summary), 1)
+	assert.Equal(t, summary[0].Name, "foo: 2/2 running, CPU 50.00% of 0.50 vCPU, memory 10.00% of 1GiB")
+	assert.DeepEqual(t, summary[0].Processes, [][]string{
+		{"111", "RUNNING", "0.25 vCPU", "512MiB", ""},
+		{"222", "RUNNING", "0.25 vCPU", "512MiB", ""},
+	})
+}
@@ -0,0 +1,123 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/docker/compose/v2/pkg/progress"
+)
+
+// Stop scales services down to zero, so the application doesn't cost anything but the CloudFormation stack is preserved.
+// Previous desired count and auto scaling min capacity are recorded as service tags, so Start can restore them.
+func (b *ComposeECS) Stop(ctx context.Context, project *types.Project, options api.StopOptions) error {
+	return progress.Run(ctx, func(ctx context.Context) error {
+		return b.stop(ctx, project.Name, options.Services)
+	})
+}
+
+func (b *ComposeECS) stop(ctx context.Context, project string, services []string) error {
+	cluster, err := b.aws.GetStackClusterID(ctx, project)
+	if err != nil {
+		return err
+	}
+	resources, err := b.aws.ListStackResources(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	w := progress.ContextWriter(ctx)
+	var stopped stackResources
+	err = selectServices(resources, services).apply(awsTypeService, func(r stackResource) error {
+		tags, err := b.aws.GetServiceTags(ctx, r.ARN)
+		if err != nil {
+			return err
+		}
+		if _, ok := tags[desiredCountTag]; ok {
+			w.Event(progress.StoppedEvent(r.LogicalID))
+			return nil
+		}
+
+		w.Event(progress.StoppingEvent(r.LogicalID))
+		service, err := b.aws.DescribeService(ctx, cluster, r.ARN)
+		if err != nil {
+			return err
+		}
+		record := map[string]string{
+			desiredCountTag: strconv.Itoa(service.Desired),
+		}
+		target, err := b.aws.GetServiceScalableTarget(ctx, r.ARN)
+		if err != nil {
+			return err
+		}
+		if target != nil {
+			record[minCapacityTag] = strconv.FormatInt(aws.Int64Value(target.MinCapacity), 10)
+		}
+		if err := b.aws.TagService(ctx, r.ARN, record); err != nil {
+			return err
+		}
+		if target != nil {
+			// prevent auto scaling from scaling the service back up
+			if err := b.aws.UpdateServiceScalableTarget(ctx, r.ARN, 0, true); err != nil {
+				return err
+			}
+		}
+		if err := b.aws.ScaleService(ctx, cluster, r.ARN, 0); err != nil {
+			return err
+		}
+		stopped = append(stopped, r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var arns []string
+	for _, r := range stopped {
+		arns = append(arns, r.ARN)
+	}
+	if err := b.aws.WaitServicesStable(ctx, cluster, arns); err != nil {
+		return err
+	}
+	for _, r := range stopped {
+		w.Event(progress.StoppedEvent(r.LogicalID))
+	}
+	return nil
+}
+
+// selectServices returns stack resources, excluding ECS services not matching selected compose services.
+// All services are selected if none is set.
+func selectServices(resources stackResources, services []string) stackResources {
+	if len(services) == 0 {
+		return resources
+	}
+	selected := map[string]bool{}
+	for _, s := range services {
+		selected[serviceResourceName(s)] = true
+	}
+	var filtered stackResources
+	for _, r := range resources {
+		if r.Type != awsTypeService || selected[r.LogicalID] {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
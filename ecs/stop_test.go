@@ -0,0 +1,87 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/applicationautoscaling"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/compose/v2/pkg/api"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+)
+
+func expectStackServices(m *MockAPIMockRecorder, project string) {
+	m.GetStackClusterID(gomock.Any(), project).Return("cluster", nil)
+	m.ListStackResources(gomock.Any(), project).Return(stackResources{
+		{LogicalID: "FooService", Type: awsTypeService, ARN: "arn:foo"},
+		{LogicalID: "BarService", Type: awsTypeService, ARN: "arn:bar"},
+		{LogicalID: "FooTaskDefinition", Type: "AWS::ECS::TaskDefinition", ARN: "arn:foo-task"},
+	}, nil)
+}
+
+func TestStopService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectStackServices(m.EXPECT(), t.Name())
+
+	m.EXPECT().GetServiceTags(gomock.Any(), "arn:foo").Return(map[string]string{}, nil)
+	m.EXPECT().DescribeService(gomock.Any(), "cluster", "arn:foo").Return(api.ServiceStatus{Desired: 2}, nil)
+	m.EXPECT().GetServiceScalableTarget(gomock.Any(), "arn:foo").Return(&applicationautoscaling.ScalableTarget{
+		MinCapacity: aws.Int64(2),
+	}, nil)
+	m.EXPECT().TagService(gomock.Any(), "arn:foo", map[string]string{
+		desiredCountTag: "2",
+		minCapacityTag:  "2",
+	}).Return(nil)
+	m.EXPECT().UpdateServiceScalableTarget(gomock.Any(), "arn:foo", int64(0), true).Return(nil)
+	m.EXPECT().ScaleService(gomock.Any(), "cluster", "arn:foo", 0).Return(nil)
+	m.EXPECT().WaitServicesStable(gomock.Any(), "cluster", []string{"arn:foo"}).Return(nil)
+
+	project := &types.Project{
+		Name:     t.Name(),
+		Services: types.Services{{Name: "foo"}, {Name: "bar"}},
+	}
+	backend := &ComposeECS{aws: m}
+	err := backend.Stop(context.TODO(), project, api.StopOptions{Services: []string{"foo"}})
+	assert.NilError(t, err)
+}
+
+func TestStartService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	expectStackServices(m.EXPECT(), t.Name())
+
+	m.EXPECT().GetServiceTags(gomock.Any(), "arn:foo").Return(map[string]string{
+		desiredCountTag: "2",
+		minCapacityTag:  "1",
+	}, nil)
+	m.EXPECT().GetServiceTags(gomock.Any(), "arn:bar").Return(map[string]string{}, nil)
+	m.EXPECT().ScaleService(gomock.Any(), "cluster", "arn:foo", 2).Return(nil)
+	m.EXPECT().UpdateServiceScalableTarget(gomock.Any(), "arn:foo", int64(1), false).Return(nil)
+	m.EXPECT().UntagService(gomock.Any(), "arn:foo", []string{desiredCountTag, minCapacityTag}).Return(nil)
+	m.EXPECT().WaitServicesStable(gomock.Any(), "cluster", []string{"arn:foo"}).Return(nil)
+
+	backend := &ComposeECS{aws: m}
+	err := backend.start(context.TODO(), t.Name(), nil)
+	assert.NilError(t, err)
+}
@@ -22,6 +22,13 @@ import (
 	"github.com/docker/compose/v2/pkg/api"
 )
 
+const (
+	// desiredCountTag records the service desired count while it is stopped
+	desiredCountTag = "com.docker.compose.ecs.desired_count"
+	// minCapacityTag records the service auto scaling min capacity while it is stopped
+	minCapacityTag = "com.docker.compose.ecs.min_capacity"
+)
+
 func projectTags(project *types.Project) []tags.Tag {
 	return []tags.Tag{
 		{
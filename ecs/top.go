@@ -0,0 +1,51 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/compose/v2/pkg/api"
+)
+
+// Top lists tasks of services with their resources reservation, and services utilization as reported by CloudWatch
+func (b *ComposeECS) Top(ctx context.Context, projectName string, services []string) ([]api.ContainerProcSummary, error) {
+	stats, err := b.Stats(ctx, projectName, services)
+	if err != nil {
+		return nil, err
+	}
+	var summary []api.ContainerProcSummary
+	for _, s := range stats {
+		var processes [][]string
+		for _, t := range s.Tasks {
+			started := ""
+			if !t.StartedAt.IsZero() {
+				started = t.StartedAt.Local().Format("15:04:05")
+			}
+			processes = append(processes, []string{t.ID, t.Status, FormatCPU(t.CPU), FormatMemory(t.Memory), started})
+		}
+		summary = append(summary, api.ContainerProcSummary{
+			ID: s.Service,
+			Name: fmt.Sprintf("%s: %d/%d running, CPU %.2f%% of %s, memory %.2f%% of %s", s.Service, s.Running, s.Desired,
+				s.CPUPercent(), FormatCPU(s.CPUReserved), s.MemoryPercent(), FormatMemory(s.MemoryReserved)),
+			Titles:    []string{"TASK", "STATUS", "CPU", "MEMORY", "STARTED"},
+			Processes: processes,
+		})
+	}
+	return summary, nil
+}
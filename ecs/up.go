@@ -19,25 +19,45 @@ package ecs
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
+	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/compose-spec/compose-go/types"
 	"github.com/docker/compose/v2/pkg/api"
 	"github.com/docker/compose/v2/pkg/progress"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/docker/compose-ecs/utils"
 )
 
+// UpOptions are ECS specific options for up
+type UpOptions struct {
+	// DryRun lists the changes up would apply, without deploying
+	DryRun bool
+	// OnInterrupt is the policy applied when user interrupts deployment, one of OnInterruptDelete, OnInterruptRollback or
+	// OnInterruptDetach. By default, updates are rolled back and user is asked whether stack being created should be deleted.
+	OnInterrupt string
+}
+
 func (b *ComposeECS) Up(ctx context.Context, project *types.Project, options api.UpOptions) error {
 	if err := checkUnsupportedUpOptions(ctx, options); err != nil {
 		return err
 	}
-	return progress.Run(ctx, func(ctx context.Context) error {
+	if b.UpOptions.DryRun {
+		return b.upDryRun(ctx, project, options)
+	}
+	if err := checkOnInterruptPolicy(b.UpOptions.OnInterrupt); err != nil {
+		return err
+	}
+	err := progress.Run(ctx, func(ctx context.Context) error {
 		return b.up(ctx, project, options)
 	})
+	var interrupted *deploymentInterrupted
+	if !errors.As(err, &interrupted) {
+		return err
+	}
+	return b.onInterrupt(ctx, interrupted)
 }
 
 func (b *ComposeECS) up(ctx context.Context, project *types.Project, options api.UpOptions) error {
@@ -54,66 +74,139 @@ func (b *ComposeECS) up(ctx context.Context, project *types.Project, options api
 		return err
 	}
 
-	update, err := b.aws.StackExists(ctx, project.Name)
+	b.recordPendingDeployment(ctx, project.Name)
+	operation, previousEvents, err := b.deployStack(ctx, project.Name, template)
 	if err != nil {
 		return err
 	}
 
-	var previousEvents []string
-	if update {
-		var err error
-		previousEvents, err = b.previousStackEvents(ctx, project.Name)
-		if err != nil {
+	// orphans and services health are only known once stack is deployed
+	if options.Start.Attach == nil && !options.Create.RemoveOrphans && !options.Start.Wait {
+		b.deferDeployment(ctx, project.Name, projectHash(project), template)
+		return nil
+	}
+	waitCtx := ctx
+	if timeout := waitTimeout(options); options.Start.Wait && timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err = b.WaitStackCompletion(waitCtx, project.Name, operation, previousEvents...)
+	if ctx.Err() == context.Canceled {
+		return &deploymentInterrupted{stack: project.Name, operation: operation}
+	}
+	if waitCtx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timeout waiting for stack %s to be deployed", project.Name)
+	}
+	if err != nil {
+		return err
+	}
+	b.recordDeployment(ctx, project.Name, projectHash(project), template)
+
+	if options.Start.Wait {
+		if err := b.waitProjectServices(waitCtx, project.Name); err != nil {
 			return err
 		}
 	}
+	if !options.Create.RemoveOrphans {
+		return nil
+	}
+	return b.removeProjectOrphans(ctx, project)
+}
+
+// deployStack submits template for stack, through a change set if stack exists. It returns the operation to wait for, and the
+// events of previous deployments
+func (b *ComposeECS) deployStack(ctx context.Context, name string, template []byte) (int, []string, error) {
+	changeSetType, err := b.changeSetType(ctx, name)
+	if err != nil {
+		return 0, nil, err
+	}
 
 	operation := stackCreate
-	if update {
+	var previousEvents []string
+	if changeSetType == cloudformation.ChangeSetTypeUpdate {
 		operation = stackUpdate
-		changeset, err := b.aws.CreateChangeSet(ctx, project.Name, b.Region, template)
+		previousEvents, err = b.previousStackEvents(ctx, name)
 		if err != nil {
-			return err
-		}
-		err = b.aws.UpdateStack(ctx, changeset)
-		if err != nil {
-			return err
-		}
-	} else {
-		err = b.aws.CreateStack(ctx, project.Name, b.Region, template)
-		if err != nil {
-			return err
+			return 0, nil, err
 		}
 	}
-	if options.Start.Attach == nil {
-		return nil
+
+	if changeSetType == "" {
+		err = b.aws.CreateStack(ctx, name, b.Region, template)
+		return operation, previousEvents, err
 	}
-	signalChan := make(chan os.Signal, 1)
-	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		<-signalChan
-		fmt.Println("user interrupted deployment. Deleting stack...")
-		b.Down(ctx, project.Name, api.DownOptions{}) // nolint:errcheck
-	}()
+	changeset, err := b.aws.CreateChangeSet(ctx, name, changeSetType, b.Region, template)
+	if err != nil {
+		return 0, nil, err
+	}
+	err = b.aws.UpdateStack(ctx, changeset)
+	return operation, previousEvents, err
+}
 
-	err = b.WaitStackCompletion(ctx, project.Name, operation, previousEvents...)
-	return err
+// waitTimeout is the `--wait-timeout`, or `--timeout` as ECS has no containers to stop
+func waitTimeout(options api.UpOptions) time.Duration {
+	if options.Start.WaitTimeout > 0 {
+		return options.Start.WaitTimeout
+	}
+	if options.Create.Timeout != nil {
+		return *options.Create.Timeout
+	}
+	return 0
+}
+
+// waitProjectServices waits for project services to reach steady state with healthy load balancer targets, as CloudFormation
+// completes once tasks are started
+func (b *ComposeECS) waitProjectServices(ctx context.Context, projectName string) error {
+	cluster, err := b.aws.GetStackClusterID(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	resources, err := b.aws.ListStackResources(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	var services stackResources
+	for _, r := range resources {
+		if r.Type == awsTypeService {
+			services = append(services, r)
+		}
+	}
+	return b.waitServicesRollout(ctx, cluster, services, func(id string) progress.Event {
+		return progress.NewEvent(id, progress.Done, "Healthy")
+	})
+}
+
+func (b *ComposeECS) removeProjectOrphans(ctx context.Context, project *types.Project) error {
+	inUse, err := b.deployedTaskDefinitions(ctx, project.Name)
+	if err != nil {
+		return err
+	}
+	orphans, err := b.listOrphans(ctx, project.Name, project, inUse)
+	if err != nil {
+		return err
+	}
+	return b.removeOrphans(ctx, orphans)
 }
 
 func checkUnsupportedUpOptions(ctx context.Context, o api.UpOptions) error {
 	var errs error
+	// timeout only applies to --wait
+	timeout := o.Create.Timeout
+	if o.Start.Wait {
+		timeout = nil
+	}
 	checks := []struct {
 		toCheck, expected interface{}
 		option            string
 	}{
 		{o.Create.Inherit, true, "renew-anon-volumes"},
-		{o.Create.RemoveOrphans, false, "remove-orphans"},
 		{o.Create.QuietPull, false, "quiet-pull"},
 		{o.Create.Recreate, api.RecreateDiverged, "force-recreate"},
 		{o.Create.RecreateDependencies, api.RecreateDiverged, "always-recreate-deps"},
 		{len(o.Start.AttachTo), 0, "attach-dependencies"},
 		{len(o.Start.ExitCodeFrom), 0, "exit-code-from"},
-		{o.Create.Timeout, nil, "timeout"},
+		{timeout, nil, "timeout"},
 	}
 	for _, c := range checks {
 		errs = utils.CheckUnsupported(ctx, errs, c.toCheck, c.expected, "up", c.option)
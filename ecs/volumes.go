@@ -109,6 +109,12 @@ type VolumeCreateOptions struct {
 	ThroughputMode               string
 }
 
+// projectFileSystem is an EFS file system created for a project volume
+type projectFileSystem struct {
+	Volume string
+	ID     string
+}
+
 type ecsVolumeService struct {
 	backend *ComposeECS
 }
@@ -24,8 +24,10 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/docker/compose/v2/pkg/progress"
 	"github.com/iancoleman/strcase"
+	"github.com/pkg/errors"
 )
 
 func (b *ComposeECS) WaitStackCompletion(ctx context.Context, name string, operation int, ignored ...string) error { //nolint:gocyclo
@@ -43,7 +45,7 @@ func (b *ComposeECS) WaitStackCompletion(ctx context.Context, name string, opera
 	}
 
 	ticker := time.NewTicker(1 * time.Second)
-	done := make(chan bool)
+	done := make(chan bool, 1)
 	go func() {
 		b.aws.WaitStackComplete(ctx, stackID, operation) //nolint:errcheck
 		ticker.Stop()
@@ -96,7 +98,7 @@ func (b *ComposeECS) WaitStackCompletion(ctx context.Context, name string, opera
 					progressStatus = progress.Error
 					if stackErr == nil {
 						operation = stackDelete
-						stackErr = fmt.Errorf(reason)
+						stackErr = deploymentFailure(event)
 					}
 				}
 			}
@@ -118,6 +120,23 @@ func (b *ComposeECS) WaitStackCompletion(ctx context.Context, name string, opera
 	return stackErr
 }
 
+// deploymentFailure returns the error for a failed stack event. ECS services failing as their deployment circuit breaker or alarms
+// have been triggered are reported explicitly, as ECS rolls those back to the last completed deployment unless configured otherwise
+func deploymentFailure(event *cloudformation.StackEvent) error {
+	reason := aws.StringValue(event.ResourceStatusReason)
+	if aws.StringValue(event.ResourceType) != awsTypeService {
+		return errors.New(reason)
+	}
+	resource := aws.StringValue(event.LogicalResourceId)
+	switch lower := strings.ToLower(reason); {
+	case strings.Contains(lower, "circuit breaker"):
+		return fmt.Errorf("service %s deployment failed, circuit breaker triggered: %s", resource, reason)
+	case strings.Contains(lower, "deployment alarms were triggered"):
+		return fmt.Errorf("service %s deployment failed, deployment alarm triggered: %s", resource, reason)
+	}
+	return errors.New(reason)
+}
+
 func toCamelCase(status string) string {
 	return strcase.ToCamel(strings.ToLower(status))
 }
@@ -19,9 +19,41 @@ package ecs
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"gotest.tools/v3/assert"
 )
 
 func TestStatusCamelCase(t *testing.T) {
 	assert.Equal(t, toCamelCase("CREATE_IN_PROGRESS"), "CreateInProgress")
 }
+
+func TestDeploymentFailure(t *testing.T) {
+	err := deploymentFailure(&cloudformation.StackEvent{
+		LogicalResourceId:    aws.String("FooService"),
+		ResourceType:         aws.String(awsTypeService),
+		ResourceStatusReason: aws.String("Error occurred during operation 'ECS Deployment Circuit Breaker was triggered'."),
+	})
+	assert.Error(t, err, "service FooService deployment failed, circuit breaker triggered: Error occurred during operation 'ECS Deployment Circuit Breaker was triggered'.")
+
+	err = deploymentFailure(&cloudformation.StackEvent{
+		LogicalResourceId:    aws.String("FooService"),
+		ResourceType:         aws.String(awsTypeService),
+		ResourceStatusReason: aws.String("ECS Deployment Alarms were triggered: [FooLatencyAlarm]"),
+	})
+	assert.ErrorContains(t, err, "deployment alarm triggered")
+
+	err = deploymentFailure(&cloudformation.StackEvent{
+		LogicalResourceId:    aws.String("FooService"),
+		ResourceType:         aws.String(awsTypeService),
+		ResourceStatusReason: aws.String("Resource handler returned message: \"Invalid request provided: alarm FooLatencyAlarm does not exist\""),
+	})
+	assert.Error(t, err, "Resource handler returned message: \"Invalid request provided: alarm FooLatencyAlarm does not exist\"")
+
+	err = deploymentFailure(&cloudformation.StackEvent{
+		LogicalResourceId:    aws.String("FooLoadBalancer"),
+		ResourceType:         aws.String("AWS::ElasticLoadBalancingV2::LoadBalancer"),
+		ResourceStatusReason: aws.String("Resource limit exceeded"),
+	})
+	assert.Error(t, err, "Resource limit exceeded")
+}
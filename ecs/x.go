@@ -26,6 +26,9 @@ const (
 	extensionKeys            = "x-aws-keys"
 	extensionMinPercent      = "x-aws-min_percent"
 	extensionMaxPercent      = "x-aws-max_percent"
+	extensionCircuitBreaker  = "x-aws-circuit_breaker"
+	extensionAlarms          = "x-aws-alarms"
+	extensionBlueGreen       = "x-aws-blue_green"
 	extensionRetention       = "x-aws-logs_retention"
 	extensionRole            = "x-aws-role"
 	extensionManagedPolicies = "x-aws-policies"